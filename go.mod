@@ -5,6 +5,7 @@ go 1.18
 require (
 	github.com/edgexfoundry/go-mod-core-contracts/v2 v2.3.0
 	github.com/spf13/cobra v1.3.0
+	github.com/spf13/pflag v1.0.5
 )
 
 require (
@@ -15,7 +16,6 @@ require (
 	github.com/google/uuid v1.3.0 // indirect
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/leodido/go-urn v1.2.1 // indirect
-	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/x448/float16 v0.8.4 // indirect
 	golang.org/x/crypto v0.0.0-20211215153901-e495a2d5b3d3 // indirect
 	golang.org/x/sys v0.0.0-20211205182925-97ca703d548d // indirect
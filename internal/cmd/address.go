@@ -0,0 +1,163 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+)
+
+// parseHostPort parses an address of the form host:port
+func parseHostPort(hostPort string) (string, int, error) {
+	i := strings.LastIndex(hostPort, ":")
+	if i < 1 {
+		return "", 0, fmt.Errorf("invalid address %q, expected host:port", hostPort)
+	}
+	port, err := strconv.Atoi(hostPort[i+1:])
+	if err != nil || port < 1 || port > 65535 {
+		return "", 0, fmt.Errorf("invalid port in address %q", hostPort)
+	}
+	return hostPort[:i], port, nil
+}
+
+// parseAddressOptions parses a comma-delimited list of key=value options
+func parseAddressOptions(options []string) (map[string]string, error) {
+	result := make(map[string]string, len(options))
+	for _, option := range options {
+		kv := strings.SplitN(option, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid option %q, expected key=value", option)
+		}
+		result[strings.ToLower(kv[0])] = kv[1]
+	}
+	return result, nil
+}
+
+// parseRESTAddress parses a REST address of the form host:port[/path][,method=POST]
+func parseRESTAddress(spec string) (dtos.Address, error) {
+	parts := strings.Split(spec, ",")
+	target := parts[0]
+	path := ""
+	if i := strings.Index(target, "/"); i >= 0 {
+		target, path = target[:i], target[i:]
+	}
+	host, port, err := parseHostPort(target)
+	if err != nil {
+		return dtos.Address{}, err
+	}
+	options, err := parseAddressOptions(parts[1:])
+	if err != nil {
+		return dtos.Address{}, err
+	}
+
+	method := "POST"
+	for key, value := range options {
+		switch key {
+		case "method":
+			method = strings.ToUpper(value)
+		default:
+			return dtos.Address{}, fmt.Errorf("unknown REST address option %q", key)
+		}
+	}
+
+	address := dtos.NewRESTAddress(host, port, method)
+	address.Path = path
+	return address, validateAddress(address)
+}
+
+// parseMQTTAddress parses an MQTT address of the form
+// host:port,topic=TOPIC[,publisher=NAME][,qos=N][,retained=true][,keepalive=N][,autoreconnect=true][,connecttimeout=N]
+func parseMQTTAddress(spec string) (dtos.Address, error) {
+	parts := strings.Split(spec, ",")
+	host, port, err := parseHostPort(parts[0])
+	if err != nil {
+		return dtos.Address{}, err
+	}
+	options, err := parseAddressOptions(parts[1:])
+	if err != nil {
+		return dtos.Address{}, err
+	}
+
+	address := dtos.NewMQTTAddress(host, port, "edgex-cli", "")
+	for key, value := range options {
+		switch key {
+		case "topic":
+			address.Topic = value
+		case "publisher":
+			address.Publisher = value
+		case "qos":
+			address.QoS, err = strconv.Atoi(value)
+		case "keepalive":
+			address.KeepAlive, err = strconv.Atoi(value)
+		case "connecttimeout":
+			address.ConnectTimeout, err = strconv.Atoi(value)
+		case "retained":
+			address.Retained, err = strconv.ParseBool(value)
+		case "autoreconnect":
+			address.AutoReconnect, err = strconv.ParseBool(value)
+		default:
+			return dtos.Address{}, fmt.Errorf("unknown MQTT address option %q", key)
+		}
+		if err != nil {
+			return dtos.Address{}, fmt.Errorf("invalid value %q for MQTT address option %s", value, key)
+		}
+	}
+	if address.QoS < 0 || address.QoS > 2 {
+		return dtos.Address{}, fmt.Errorf("MQTT QoS should be 0, 1 or 2")
+	}
+	return address, validateAddress(address)
+}
+
+// parseEmailAddress parses a comma-delimited list of email recipients
+func parseEmailAddress(spec string) (dtos.Address, error) {
+	var recipients []string
+	for _, r := range strings.Split(spec, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			recipients = append(recipients, r)
+		}
+	}
+	address := dtos.NewEmailAddress(recipients)
+	return address, validateAddress(address)
+}
+
+// validateAddress validates the address locally, the same way the services do
+func validateAddress(address dtos.Address) error {
+	if err := address.Validate(); err != nil {
+		return fmt.Errorf("invalid %s address: %v", address.Type, err)
+	}
+	return nil
+}
+
+// formatAddress returns a short description of where an address sends to
+func formatAddress(address dtos.Address) string {
+	switch address.Type {
+	case common.REST:
+		u := url.URL{Scheme: "http", Host: address.Host + ":" + strconv.Itoa(address.Port), Path: address.Path}
+		return fmt.Sprintf("%s %s %s", address.Type, address.HTTPMethod, u.String())
+	case common.MQTT:
+		return fmt.Sprintf("%s %s:%d %s", address.Type, address.Host, address.Port, address.Topic)
+	case common.EMAIL:
+		return fmt.Sprintf("%s %s", address.Type, strings.Join(address.Recipients, ","))
+	default:
+		return fmt.Sprintf("%s %s:%d", address.Type, address.Host, address.Port)
+	}
+}
@@ -0,0 +1,200 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+)
+
+func TestParseHostPort(t *testing.T) {
+	tests := []struct {
+		name         string
+		hostPort     string
+		expectedHost string
+		expectedPort int
+		expectError  bool
+	}{
+		{"host and port", "localhost:8080", "localhost", 8080, false},
+		{"IPv6 host", "[::1]:8080", "[::1]", 8080, false},
+		{"missing port", "localhost", "", 0, true},
+		{"missing host", ":8080", "", 0, true},
+		{"invalid port", "localhost:http", "", 0, true},
+		{"port out of range", "localhost:65536", "", 0, true},
+		{"zero port", "localhost:0", "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			host, port, err := parseHostPort(tt.hostPort)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %s %d", host, port)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if host != tt.expectedHost || port != tt.expectedPort {
+				t.Errorf("expected %s %d, got %s %d", tt.expectedHost, tt.expectedPort, host, port)
+			}
+		})
+	}
+}
+
+func TestParseRESTAddress(t *testing.T) {
+	withPath := func(address dtos.Address, path string) dtos.Address {
+		address.Path = path
+		return address
+	}
+	tests := []struct {
+		name        string
+		spec        string
+		expected    dtos.Address
+		expectError bool
+	}{
+		{"host and port", "localhost:7770", dtos.NewRESTAddress("localhost", 7770, "POST"), false},
+		{"path", "localhost:7770/api/v2/ping", withPath(dtos.NewRESTAddress("localhost", 7770, "POST"), "/api/v2/ping"), false},
+		{"method", "localhost:7770/ping,method=get", withPath(dtos.NewRESTAddress("localhost", 7770, "GET"), "/ping"), false},
+		{"method key is case insensitive", "localhost:7770,METHOD=PUT", dtos.NewRESTAddress("localhost", 7770, "PUT"), false},
+		{"invalid method", "localhost:7770,method=FETCH", dtos.Address{}, true},
+		{"unknown option", "localhost:7770,topic=x", dtos.Address{}, true},
+		{"option without value", "localhost:7770,method", dtos.Address{}, true},
+		{"missing port", "localhost/ping", dtos.Address{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseRESTAddress(tt.spec)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseMQTTAddress(t *testing.T) {
+	mqttAddress := func(topic string, update func(a *dtos.Address)) dtos.Address {
+		address := dtos.NewMQTTAddress("broker", 1883, "edgex-cli", topic)
+		if update != nil {
+			update(&address)
+		}
+		return address
+	}
+	tests := []struct {
+		name        string
+		spec        string
+		expected    dtos.Address
+		expectError bool
+	}{
+		{"topic", "broker:1883,topic=edgex/alerts", mqttAddress("edgex/alerts", nil), false},
+		{"all options", "broker:1883,topic=t,publisher=me,qos=2,retained=true,keepalive=30,autoreconnect=true,connecttimeout=5",
+			mqttAddress("t", func(a *dtos.Address) {
+				a.Publisher = "me"
+				a.QoS = 2
+				a.Retained = true
+				a.KeepAlive = 30
+				a.AutoReconnect = true
+				a.ConnectTimeout = 5
+			}), false},
+		{"missing topic", "broker:1883", dtos.Address{}, true},
+		{"QoS out of range", "broker:1883,topic=t,qos=3", dtos.Address{}, true},
+		{"invalid QoS", "broker:1883,topic=t,qos=high", dtos.Address{}, true},
+		{"invalid boolean", "broker:1883,topic=t,retained=maybe", dtos.Address{}, true},
+		{"unknown option", "broker:1883,topic=t,method=GET", dtos.Address{}, true},
+		{"missing port", "broker,topic=t", dtos.Address{}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseMQTTAddress(tt.spec)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseEmailAddress(t *testing.T) {
+	tests := []struct {
+		name        string
+		spec        string
+		expected    []string
+		expectError bool
+	}{
+		{"single recipient", "ops@example.com", []string{"ops@example.com"}, false},
+		{"several recipients", "ops@example.com, dev@example.com", []string{"ops@example.com", "dev@example.com"}, false},
+		{"empty entries are ignored", "ops@example.com,,", []string{"ops@example.com"}, false},
+		{"no recipients", " , ", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseEmailAddress(tt.spec)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(actual.Recipients, tt.expected) {
+				t.Errorf("expected recipients %v, got %v", tt.expected, actual.Recipients)
+			}
+		})
+	}
+}
+
+func TestFormatAddress(t *testing.T) {
+	rest := dtos.NewRESTAddress("localhost", 7770, "PUT")
+	rest.Path = "/ping"
+	tests := []struct {
+		name     string
+		address  dtos.Address
+		expected string
+	}{
+		{"REST", rest, "REST PUT http://localhost:7770/ping"},
+		{"MQTT", dtos.NewMQTTAddress("broker", 1883, "edgex-cli", "alerts"), "MQTT broker:1883 alerts"},
+		{"EMAIL", dtos.NewEmailAddress([]string{"a@example.com", "b@example.com"}), "EMAIL a@example.com,b@example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := formatAddress(tt.address); actual != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, actual)
+			}
+		})
+	}
+}
@@ -21,7 +21,6 @@ import (
 	jsonpkg "encoding/json"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"os"
 	"text/tabwriter"
 
@@ -38,12 +37,13 @@ func init() {
 	initReadCommand(commandCmd)
 	initWriteCommand(commandCmd)
 	initListCommand(commandCmd)
+	initWatchCommand(commandCmd)
 }
 
 func initCommandCommand() *cobra.Command {
 	var cmd = &cobra.Command{
 		Use:          "command",
-		Short:        "Read, write and list commands [Core Command]",
+		Short:        "Read, write, watch and list commands [Core Command]",
 		Long:         "",
 		SilenceUsage: true,
 	}
@@ -53,9 +53,13 @@ func initCommandCommand() *cobra.Command {
 
 func initReadCommand(cmd *cobra.Command) {
 	var readCmd = &cobra.Command{
-		Use:          "read",
-		Short:        "Issue a read command to the specified device",
-		Long:         "Issue a read command to the specified device",
+		Use:   "read",
+		Short: "Issue a read command to the specified device or devices",
+		Long: `Issue a read command to the specified device, or to all the devices matching the
+--labels, --profile, --service and --devices-file flags`,
+		Example: `  edgex-cli command read -d "device01" -c "temperature"
+  edgex-cli command read -c "temperature" --profile "thermostat" --concurrency 16
+  edgex-cli command read -d "camera01" -c "snapshot" --query "resolution=high" --save-binary ./images`,
 		RunE:         handleReadCommand,
 		SilenceUsage: true,
 	}
@@ -63,17 +67,22 @@ func initReadCommand(cmd *cobra.Command) {
 	readCmd.Flags().StringVarP(&commandName, "command", "c", "", "specify the name of the command to be executed")
 	readCmd.Flags().BoolVarP(&pushEvent, "pushevent", "p", false, "if set, a successful read command will result in an event being pushed to the EdgeX system")
 	readCmd.Flags().BoolVarP(&noReturnEvent, "noreturnevent", "r", false, "if set, there will be no event returned in the HTTP response")
-	readCmd.MarkFlagRequired("device")
 	readCmd.MarkFlagRequired("command")
+	addReadValueFlags(readCmd)
+	addBatchReadFlags(readCmd)
 	cmd.AddCommand(readCmd)
 	addFormatFlags(readCmd)
 }
 
 func initWriteCommand(cmd *cobra.Command) {
 	var writeCmd = &cobra.Command{
-		Use:          "write",
-		Short:        "Issue a write command to the specified device",
-		Long:         "Issue a write command to the specified device",
+		Use:   "write",
+		Short: "Issue a write command to the specified device",
+		Long: `Issue a write command to the specified device. Unless --no-validate is set, the values are first
+checked against the device profile: the resources must be writable parts of the command, and the
+values must be of the resources' value types and within their minimum and maximum.`,
+		Example: `  edgex-cli command write -d "device01" -c "setpoint" --set "Temperature=21.5" --set "Mode=heat"
+  edgex-cli command write -d "device01" -c "setpoint" -b "{\"Temperature\": \"21.5\"}"`,
 		RunE:         handleWriteCommand,
 		SilenceUsage: true,
 	}
@@ -81,6 +90,7 @@ func initWriteCommand(cmd *cobra.Command) {
 	writeCmd.Flags().StringVarP(&commandName, "command", "c", "", "specify the name of the command to be executed")
 	writeCmd.Flags().StringVarP(&requestBody, "body", "b", "", "specify the write command's request body, which provides the value(s) being written to the device")
 	writeCmd.Flags().StringVarP(&requestFile, "file", "f", "", "specify a file containing the write command's request body, which provides the value(s) being written to the device")
+	addWriteSettingsFlags(writeCmd)
 	writeCmd.MarkFlagRequired("device")
 	writeCmd.MarkFlagRequired("command")
 	cmd.AddCommand(writeCmd)
@@ -99,6 +109,7 @@ func initListCommand(cmd *cobra.Command) {
 	addLimitOffsetFlags(listCmd)
 	cmd.AddCommand(listCmd)
 	addFormatFlags(listCmd)
+	addTableFlags(listCmd)
 }
 
 func handleReadCommand(cmd *cobra.Command, args []string) error {
@@ -113,7 +124,21 @@ func handleReadCommand(cmd *cobra.Command, args []string) error {
 	dsPushEvent := boolToString(pushEvent)
 	dsReturnEvent := boolToString(!noReturnEvent)
 
-	response, err := getCoreCommandService().GetCommandClient().IssueGetCommandByName(context.Background(), commandDeviceName, commandName, dsPushEvent, dsReturnEvent)
+	if err := prepareBinaryDir(); err != nil {
+		return err
+	}
+
+	if isBatchRead() {
+		if commandDeviceName != "" {
+			return errors.New("either specify a device name or select devices using --labels, --profile, --service or --devices-file, but not both")
+		}
+		return handleBatchReadCommand(dsPushEvent, dsReturnEvent)
+	}
+	if commandDeviceName == "" {
+		return errors.New("a device name must be specified, or devices selected using --labels, --profile, --service or --devices-file")
+	}
+
+	response, err := issueReadCommand(context.Background(), commandDeviceName, dsPushEvent, dsReturnEvent)
 	if err != nil {
 		return err
 	}
@@ -124,9 +149,14 @@ func handleReadCommand(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	event, err := getTypedEvent(response.Event)
+	if err != nil {
+		return err
+	}
+
 	// print READ command's output with one of these formats: JSON or table
 	if json {
-		stringifiedResponse, err := jsonpkg.Marshal(response)
+		stringifiedResponse, err := jsonpkg.Marshal(typedEventResponse{BaseResponse: response.BaseResponse, Event: event})
 		if err != nil {
 			return err
 		}
@@ -135,33 +165,28 @@ func handleReadCommand(cmd *cobra.Command, args []string) error {
 	} else {
 		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
 		fmt.Fprintln(w, "Command Name\tDevice Name\tProfile Name\tValue Type\tValue")
-		for _, reading := range response.Event.Readings {
+		for _, reading := range event.Readings {
 			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-				commandName, reading.DeviceName, reading.ProfileName, reading.ValueType, reading.Value)
+				commandName, reading.DeviceName, reading.ProfileName, reading.ValueType, formatTypedReadingValue(reading))
 		}
 		w.Flush()
+		return printObjectReadings(event)
 	}
 	return nil
 }
 
 func handleWriteCommand(cmd *cobra.Command, args []string) error {
-	// issue WRITE command's request body by one of these options: inline body or using file
-	if (requestBody != "" && requestFile != "") || (requestBody == "" && requestFile == "") {
-		return errors.New("please specify request data using one of the provided ways: --body or --file")
+	// issue WRITE command's request body by one of these options: inline body, using file or --set flags
+	settings, err := getWriteSettings()
+	if err != nil {
+		return err
 	}
 
-	if requestFile != "" {
-		content, err := ioutil.ReadFile(requestFile)
+	if !noValidate {
+		err = validateWriteSettings(commandDeviceName, commandName, settings)
 		if err != nil {
 			return err
 		}
-		requestBody = string(content)
-	}
-
-	var settings map[string]string
-	err := jsonpkg.Unmarshal([]byte(requestBody), &settings)
-	if err != nil {
-		return err
 	}
 
 	// issue write command
@@ -201,14 +226,7 @@ func handleListCommand(cmd *cobra.Command, args []string) error {
 			}
 			fmt.Println(string(stringified))
 		} else {
-			w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-			fmt.Fprintln(w, "Name\tDevice Name\tProfile Name\tMethods\tURL")
-			for _, command := range response.DeviceCoreCommand.CoreCommands {
-				methods := methodsToString(command)
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-					command.Name, response.DeviceCoreCommand.DeviceName, response.DeviceCoreCommand.ProfileName, methods, command.Url+command.Path)
-			}
-			w.Flush()
+			return printCoreCommands([]dtos.DeviceCoreCommand{response.DeviceCoreCommand})
 		}
 
 	} else {
@@ -228,22 +246,41 @@ func handleListCommand(cmd *cobra.Command, args []string) error {
 
 			fmt.Println(string(stringified))
 		} else {
-			w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-			fmt.Fprintln(w, "Name\tDevice Name\tProfile Name\tMethods\tURL")
-			for _, device := range response.DeviceCoreCommands {
-				for _, command := range device.CoreCommands {
-					methods := methodsToString(command)
-					fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-						command.Name, device.DeviceName, device.ProfileName, methods, command.Url+command.Path)
-				}
-			}
-			w.Flush()
+			return printCoreCommands(response.DeviceCoreCommands)
 		}
 	}
 
 	return nil
 }
 
+// coreCommandRow is a row of the command list table, a core command of a device
+type coreCommandRow struct {
+	command dtos.CoreCommand
+	device  *dtos.DeviceCoreCommand
+}
+
+// coreCommandTable defines the columns of tables of core commands
+var coreCommandTable = table{
+	columns: []column{
+		newColumn("Name", func(r *coreCommandRow) interface{} { return r.command.Name }),
+		newColumn("Device Name", func(r *coreCommandRow) interface{} { return r.device.DeviceName }),
+		newColumn("Profile Name", func(r *coreCommandRow) interface{} { return r.device.ProfileName }),
+		newColumn("Methods", func(r *coreCommandRow) interface{} { return methodsToString(r.command) }),
+		newColumn("URL", func(r *coreCommandRow) interface{} { return r.command.Url + r.command.Path }),
+	},
+	defaults: []string{"Name", "Device Name", "Profile Name", "Methods", "URL"},
+}
+
+func printCoreCommands(devices []dtos.DeviceCoreCommand) error {
+	var rows []coreCommandRow
+	for i := range devices {
+		for _, command := range devices[i].CoreCommands {
+			rows = append(rows, coreCommandRow{command: command, device: &devices[i]})
+		}
+	}
+	return printRows(coreCommandTable, rows)
+}
+
 // used by list command when it shows in table format
 func methodsToString(command dtos.CoreCommand) string {
 	if command.Get && command.Set {
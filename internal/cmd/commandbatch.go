@@ -0,0 +1,246 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"context"
+	jsonpkg "encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/responses"
+	"github.com/spf13/cobra"
+)
+
+var commandProfileName, commandServiceName, commandDevicesFile string
+var commandConcurrency int
+
+// addBatchReadFlags adds the flags selecting the devices a read command is issued to
+func addBatchReadFlags(cmd *cobra.Command) {
+	addLabelsFlag(cmd)
+	cmd.Flags().StringVarP(&commandProfileName, "profile", "", "", "Issue the command to the devices using this device profile")
+	cmd.Flags().StringVarP(&commandServiceName, "service", "", "", "Issue the command to the devices managed by this device service")
+	cmd.Flags().StringVarP(&commandDevicesFile, "devices-file", "", "", "Issue the command to the devices named in this file, one per line")
+	cmd.Flags().IntVarP(&commandConcurrency, "concurrency", "", 8, "Maximum number of devices the command is issued to in parallel")
+}
+
+// isBatchRead returns true if the read command selects the devices using the batch flags
+func isBatchRead() bool {
+	return labels != "" || commandProfileName != "" || commandServiceName != "" || commandDevicesFile != ""
+}
+
+// commandReadResult is the outcome of issuing a read command to a device
+type commandReadResult struct {
+	DeviceName string      `json:"deviceName"`
+	Latency    string      `json:"latency"`
+	Error      string      `json:"error,omitempty"`
+	Event      *typedEvent `json:"event,omitempty"`
+}
+
+func handleBatchReadCommand(dsPushEvent string, dsReturnEvent string) error {
+	if commandConcurrency < 1 {
+		return errors.New("the concurrency must be at least 1")
+	}
+	devices, err := getBatchDevices()
+	if err != nil {
+		return err
+	}
+	if len(devices) == 0 {
+		return errors.New("no devices match the given selection")
+	}
+
+	results := make([]commandReadResult, len(devices))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < commandConcurrency && i < len(devices); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				started := time.Now()
+				response, err := issueReadCommand(context.Background(), devices[i], dsPushEvent, dsReturnEvent)
+				result := commandReadResult{DeviceName: devices[i], Latency: time.Since(started).Round(time.Millisecond).String()}
+				if err == nil && response != nil {
+					// the event is left out when it can't be processed, e.g. when saving a binary
+					// value fails, so that the device shows up as failed rather than empty
+					var event typedEvent
+					if event, err = getTypedEvent(response.Event); err == nil {
+						result.Event = &event
+					}
+				}
+				if err != nil {
+					result.Error = err.Error()
+				}
+				results[i] = result
+			}
+		}()
+	}
+	for i := range devices {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	failed := 0
+	for _, r := range results {
+		if r.Error != "" {
+			failed++
+		}
+	}
+
+	if json {
+		stringifiedResponse, err := jsonpkg.Marshal(results)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", stringifiedResponse)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(w, "Device Name\tLatency\tResource Name\tValue Type\tValue\tError")
+		for _, r := range results {
+			if r.Event == nil || len(r.Event.Readings) == 0 {
+				fmt.Fprintf(w, "%s\t%s\t\t\t\t%s\n", r.DeviceName, r.Latency, r.Error)
+				continue
+			}
+			for _, reading := range r.Event.Readings {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t\n",
+					r.DeviceName, r.Latency, reading.ResourceName, reading.ValueType, formatTypedReadingValue(reading))
+			}
+		}
+		w.Flush()
+
+		var events []typedEvent
+		for _, r := range results {
+			if r.Event != nil {
+				events = append(events, *r.Event)
+			}
+		}
+		if err := printObjectReadings(events...); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("the command failed on %d of %d devices", failed, len(devices))
+	}
+	return nil
+}
+
+// devicePage returns a page of devices
+type devicePage func(offset int, limit int) (responses.MultiDevicesResponse, error)
+
+// getBatchDevices returns the sorted names of the devices matching all the given batch flags
+func getBatchDevices() ([]string, error) {
+	client := getCoreMetaDataService().GetDeviceClient()
+	var selections [][]string
+
+	if labels != "" {
+		names, err := getDeviceNames(func(offset int, limit int) (responses.MultiDevicesResponse, error) {
+			return client.AllDevices(context.Background(), getLabels(), offset, limit)
+		})
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, names)
+	}
+	if commandProfileName != "" {
+		names, err := getDeviceNames(func(offset int, limit int) (responses.MultiDevicesResponse, error) {
+			return client.DevicesByProfileName(context.Background(), commandProfileName, offset, limit)
+		})
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, names)
+	}
+	if commandServiceName != "" {
+		names, err := getDeviceNames(func(offset int, limit int) (responses.MultiDevicesResponse, error) {
+			return client.DevicesByServiceName(context.Background(), commandServiceName, offset, limit)
+		})
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, names)
+	}
+	if commandDevicesFile != "" {
+		names, err := readDevicesFile(commandDevicesFile)
+		if err != nil {
+			return nil, err
+		}
+		selections = append(selections, names)
+	}
+
+	counts := make(map[string]int)
+	for _, names := range selections {
+		seen := make(map[string]bool, len(names))
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				counts[name]++
+			}
+		}
+	}
+	var devices []string
+	for name, count := range counts {
+		if count == len(selections) {
+			devices = append(devices, name)
+		}
+	}
+	sort.Strings(devices)
+	return devices, nil
+}
+
+func getDeviceNames(page devicePage) ([]string, error) {
+	return fetchAll(func(offset int, limit int) ([]string, uint32, error) {
+		response, err := page(offset, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		names := make([]string, len(response.Devices))
+		for i, d := range response.Devices {
+			names[i] = d.Name
+		}
+		return names, response.TotalCount, nil
+	})
+}
+
+// readDevicesFile reads device names from a file containing one name per line,
+// ignoring blank lines and lines starting with #
+func readDevicesFile(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var names []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		names = append(names, line)
+	}
+	return names, scanner.Err()
+}
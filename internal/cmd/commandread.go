@@ -0,0 +1,156 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	edgexCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/responses"
+	"github.com/spf13/cobra"
+)
+
+var commandQuery []string
+var commandBinaryDir string
+
+func addReadValueFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&commandQuery, "query", "q", nil, "A query parameter to pass to the device service as key=value, may be repeated")
+	cmd.Flags().StringVarP(&commandBinaryDir, "save-binary", "", "", "Directory to save the values of binary readings to")
+}
+
+// issueReadCommand issues the read command to the device, passing on the --query parameters
+func issueReadCommand(ctx context.Context, deviceName string, dsPushEvent string, dsReturnEvent string) (*responses.EventResponse, error) {
+	client := getCoreCommandService().GetCommandClient()
+	if len(commandQuery) == 0 {
+		response, err := client.IssueGetCommandByName(ctx, deviceName, commandName, dsPushEvent, dsReturnEvent)
+		if err != nil {
+			return nil, err
+		}
+		return response, nil
+	}
+
+	params := map[string]string{
+		edgexCommon.PushEvent:   dsPushEvent,
+		edgexCommon.ReturnEvent: dsReturnEvent,
+	}
+	for _, query := range commandQuery {
+		kv := strings.SplitN(query, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid query parameter %q, expected key=value", query)
+		}
+		params[kv[0]] = kv[1]
+	}
+	response, err := client.IssueGetCommandByNameWithQueryParams(ctx, deviceName, commandName, params)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// prepareBinaryDir creates the --save-binary directory if needed
+func prepareBinaryDir() error {
+	if commandBinaryDir == "" {
+		return nil
+	}
+	return os.MkdirAll(commandBinaryDir, 0755)
+}
+
+// typedReading is a reading whose value is decoded according to its value type. The value of
+// binary readings saved using --save-binary is replaced by the name of the file.
+type typedReading struct {
+	dtos.BaseReading
+	Value       interface{} `json:"value,omitempty"`
+	BinaryValue []byte      `json:"binaryValue,omitempty"`
+	File        string      `json:"file,omitempty"`
+}
+
+// typedEvent is an event with typed readings
+type typedEvent struct {
+	dtos.Event
+	Readings []typedReading `json:"readings"`
+}
+
+// typedEventResponse is an event response with typed readings
+type typedEventResponse struct {
+	common.BaseResponse
+	Event typedEvent `json:"event"`
+}
+
+// getTypedEvent decodes the readings of the event, saving binary values if --save-binary is set
+func getTypedEvent(event dtos.Event) (typedEvent, error) {
+	result := typedEvent{Event: event, Readings: make([]typedReading, len(event.Readings))}
+	for i, r := range event.Readings {
+		t := typedReading{BaseReading: r}
+		if r.ValueType == edgexCommon.ValueTypeBinary {
+			if commandBinaryDir != "" {
+				fileName, err := saveBinaryReading(commandBinaryDir, r)
+				if err != nil {
+					return result, err
+				}
+				t.File = fileName
+			} else {
+				t.BinaryValue = r.BinaryValue
+			}
+		} else if r.ValueType != edgexCommon.ValueTypeObject {
+			value, err := getReadingValue(r)
+			if err != nil {
+				// keep values the device service sent in an unexpected format as they are
+				value = r.Value
+			}
+			t.Value = value
+		}
+		result.Readings[i] = t
+	}
+	return result, nil
+}
+
+// formatTypedReadingValue renders the value of a reading for a table
+func formatTypedReadingValue(r typedReading) string {
+	switch r.ValueType {
+	case edgexCommon.ValueTypeBinary:
+		if r.File != "" {
+			return r.File
+		}
+		return fmt.Sprintf("<%d bytes of %s>", len(r.BinaryValue), r.MediaType)
+	case edgexCommon.ValueTypeObject:
+		return "<object>"
+	}
+	return r.BaseReading.Value
+}
+
+// printObjectReadings pretty-prints the values of the object readings of the events
+func printObjectReadings(events ...typedEvent) error {
+	for _, e := range events {
+		for _, r := range e.Readings {
+			if r.ValueType != edgexCommon.ValueTypeObject {
+				continue
+			}
+			value, err := jsonpkg.MarshalIndent(r.ObjectValue, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\n%s %s:\n%s\n", r.DeviceName, r.ResourceName, value)
+		}
+	}
+	return nil
+}
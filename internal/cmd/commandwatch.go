@@ -0,0 +1,238 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	edgexCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/spf13/cobra"
+)
+
+var commandWatchInterval, commandWatchTimeout time.Duration
+var commandWatchCount int
+var commandWatchRecord string
+
+func initWatchCommand(cmd *cobra.Command) {
+	var watchCmd = &cobra.Command{
+		Use:   "watch",
+		Short: "Repeatedly issue a read command to the specified device",
+		Long: `Repeatedly issue a read command to the specified device, showing the values returned over time.
+Values which changed since the previous read are marked with *. When stopped, either after --count
+reads or by pressing Ctrl-C, the number of errors and timeouts and the latency percentiles of the reads
+which didn't time out are shown.`,
+		Example: `  edgex-cli command watch -d "device01" -c "temperature" --interval 2s
+  edgex-cli command watch -d "device01" -c "temperature" --count 100 --interval 500ms --record temperature.jsonl`,
+		RunE:         handleWatchCommand,
+		SilenceUsage: true,
+	}
+	watchCmd.Flags().StringVarP(&commandDeviceName, "device", "d", "", "specify the name of device")
+	watchCmd.Flags().StringVarP(&commandName, "command", "c", "", "specify the name of the command to be executed")
+	watchCmd.Flags().DurationVarP(&commandWatchInterval, "interval", "i", 2*time.Second, "Time between reads")
+	watchCmd.Flags().DurationVarP(&commandWatchTimeout, "timeout", "t", 5*time.Second, "How long to wait for each read")
+	watchCmd.Flags().IntVarP(&commandWatchCount, "count", "n", 0, "Stop after this number of reads, 0 reads until interrupted")
+	watchCmd.Flags().StringVarP(&commandWatchRecord, "record", "", "", "Record every read to this file as JSON lines")
+	watchCmd.Flags().StringArrayVarP(&commandQuery, "query", "q", nil, "A query parameter to pass to the device service as key=value, may be repeated")
+	watchCmd.MarkFlagRequired("device")
+	watchCmd.MarkFlagRequired("command")
+	cmd.AddCommand(watchCmd)
+}
+
+// watchedRead is the outcome of one read of a watched command
+type watchedRead struct {
+	Time     time.Time      `json:"time"`
+	Latency  string         `json:"latency"`
+	Timeout  bool           `json:"timeout,omitempty"`
+	Error    string         `json:"error,omitempty"`
+	Readings []typedReading `json:"readings,omitempty"`
+	latency  time.Duration
+}
+
+// commandWatchSummary accumulates the statistics of a watched command
+type commandWatchSummary struct {
+	reads     int
+	timeouts  int
+	errors    int
+	latencies []float64 // of the reads which didn't time out, in nanoseconds
+	changes   map[string]int
+}
+
+func handleWatchCommand(cmd *cobra.Command, args []string) error {
+	if commandWatchInterval <= 0 {
+		return errors.New("the interval must be greater than zero")
+	}
+	if commandWatchTimeout <= 0 {
+		return errors.New("the timeout must be greater than zero")
+	}
+
+	var record io.Writer
+	if commandWatchRecord != "" {
+		f, err := os.Create(commandWatchRecord)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		record = f
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	ticker := time.NewTicker(commandWatchInterval)
+	defer ticker.Stop()
+
+	summary := commandWatchSummary{changes: make(map[string]int)}
+	previous := make(map[string]string)
+	for {
+		read := watchRead()
+		summary.reads++
+		if read.Timeout {
+			summary.timeouts++
+		} else {
+			summary.latencies = append(summary.latencies, float64(read.latency))
+			if read.Error != "" {
+				summary.errors++
+			}
+		}
+		printWatchedRead(read, previous, summary.changes)
+
+		if record != nil {
+			line, err := jsonpkg.Marshal(read)
+			if err != nil {
+				return err
+			}
+			if _, err := fmt.Fprintf(record, "%s\n", line); err != nil {
+				return err
+			}
+		}
+
+		if commandWatchCount > 0 && summary.reads >= commandWatchCount {
+			break
+		}
+		select {
+		case <-ticker.C:
+			continue
+		case <-interrupt:
+		}
+		break
+	}
+
+	printWatchSummary(summary)
+	if summary.errors+summary.timeouts > 0 {
+		return fmt.Errorf("%d of %d reads failed", summary.errors+summary.timeouts, summary.reads)
+	}
+	return nil
+}
+
+// watchRead issues the watched read command once. The clients don't cancel requests when their
+// context is done, so the request is left to complete in the background when it times out.
+func watchRead() watchedRead {
+	type result struct {
+		event typedEvent
+		err   error
+	}
+	done := make(chan result, 1)
+	started := time.Now()
+	go func() {
+		response, err := issueReadCommand(context.Background(), commandDeviceName, "no", "yes")
+		var r result
+		if err != nil {
+			r.err = err
+		} else if response != nil {
+			r.event, r.err = getTypedEvent(response.Event)
+		}
+		done <- r
+	}()
+
+	read := watchedRead{Time: started}
+	select {
+	case r := <-done:
+		read.Readings = r.event.Readings
+		if r.err != nil {
+			read.Error = r.err.Error()
+		}
+	case <-time.After(commandWatchTimeout):
+		read.Timeout = true
+		read.Error = fmt.Sprintf("timed out after %v", commandWatchTimeout)
+	}
+	read.latency = time.Since(started)
+	read.Latency = read.latency.Round(time.Millisecond).String()
+	return read
+}
+
+// printWatchedRead prints a read on one line, marking the values which changed since the previous read
+func printWatchedRead(read watchedRead, previous map[string]string, changes map[string]int) {
+	line := []string{read.Time.In(timeLocation).Format("2006-01-02 15:04:05.000"), fmt.Sprintf("%6s", read.Latency)}
+	if read.Error != "" {
+		line = append(line, "ERROR: "+read.Error)
+	}
+	for _, r := range read.Readings {
+		value := formatTypedReadingValue(r)
+		if r.ValueType == edgexCommon.ValueTypeObject {
+			if b, err := jsonpkg.Marshal(r.ObjectValue); err == nil {
+				value = string(b)
+			}
+		}
+		last, seen := previous[r.ResourceName]
+		previous[r.ResourceName] = value
+		if seen && last != value {
+			changes[r.ResourceName]++
+			value += "*"
+		}
+		line = append(line, r.ResourceName+"="+value)
+	}
+	fmt.Println(strings.Join(line, "  "))
+}
+
+func printWatchSummary(summary commandWatchSummary) {
+	fmt.Printf("\nReads: %d, succeeded: %d, timeouts: %d, errors: %d\n",
+		summary.reads, summary.reads-summary.timeouts-summary.errors, summary.timeouts, summary.errors)
+
+	if len(summary.latencies) > 0 {
+		sorted := append([]float64(nil), summary.latencies...)
+		sort.Float64s(sorted)
+		fmt.Printf("Latency: min %v, p50 %v, p90 %v, p99 %v, max %v\n",
+			formatLatency(sorted[0]),
+			formatLatency(getPercentile(sorted, 50)),
+			formatLatency(getPercentile(sorted, 90)),
+			formatLatency(getPercentile(sorted, 99)),
+			formatLatency(sorted[len(sorted)-1]))
+	}
+
+	var resources []string
+	for name := range summary.changes {
+		resources = append(resources, name)
+	}
+	sort.Strings(resources)
+	for _, name := range resources {
+		fmt.Printf("%s changed %d times\n", name, summary.changes[name])
+	}
+}
+
+// formatLatency renders a latency in nanoseconds
+func formatLatency(ns float64) time.Duration {
+	return time.Duration(ns).Round(time.Millisecond)
+}
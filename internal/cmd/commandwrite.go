@@ -0,0 +1,284 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/spf13/cobra"
+)
+
+var commandSettings []string
+var noValidate bool
+
+func addWriteSettingsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&commandSettings, "set", "", nil, "A value to write as Resource=value, may be repeated")
+	cmd.Flags().BoolVarP(&noValidate, "no-validate", "", false, "Don't validate the values against the device profile before writing them")
+}
+
+// getWriteSettings returns the values to write given using --body, --file and --set,
+// values given using --set taking precedence
+func getWriteSettings() (map[string]string, error) {
+	if requestBody != "" && requestFile != "" {
+		return nil, errors.New("please specify request data using only one of --body or --file")
+	}
+	if requestBody == "" && requestFile == "" && len(commandSettings) == 0 {
+		return nil, errors.New("please specify request data using one of the provided ways: --body, --file or --set")
+	}
+
+	if requestFile != "" {
+		content, err := ioutil.ReadFile(requestFile)
+		if err != nil {
+			return nil, err
+		}
+		requestBody = string(content)
+	}
+
+	settings := make(map[string]string)
+	if requestBody != "" {
+		err := jsonpkg.Unmarshal([]byte(requestBody), &settings)
+		if err != nil {
+			return nil, err
+		}
+	}
+	for _, setting := range commandSettings {
+		kv := strings.SplitN(setting, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, fmt.Errorf("invalid value %q, expected Resource=value", setting)
+		}
+		settings[kv[0]] = kv[1]
+	}
+	return settings, nil
+}
+
+// validateWriteSettings checks the values to write against the profile of the device:
+// the command must be writable, and each value must be for a writable resource of the
+// command, of the resource's value type and within its minimum and maximum
+func validateWriteSettings(deviceName string, commandName string, settings map[string]string) error {
+	device, err := getCoreMetaDataService().GetDeviceClient().DeviceByName(context.Background(), deviceName)
+	if err != nil {
+		return err
+	}
+	profile, err := getCoreMetaDataService().GetDeviceProfileClient().DeviceProfileByName(context.Background(), device.Device.ProfileName)
+	if err != nil {
+		return err
+	}
+
+	resources := make(map[string]dtos.DeviceResource, len(profile.Profile.DeviceResources))
+	for _, r := range profile.Profile.DeviceResources {
+		resources[r.Name] = r
+	}
+
+	// the command is either a device command or a single device resource
+	operations := make(map[string]dtos.ResourceOperation)
+	found := false
+	for _, c := range profile.Profile.DeviceCommands {
+		if c.Name != commandName {
+			continue
+		}
+		found = true
+		if !strings.Contains(c.ReadWrite, common.ReadWrite_W) {
+			return fmt.Errorf("command %s of profile %s is read-only", commandName, profile.Profile.Name)
+		}
+		for _, o := range c.ResourceOperations {
+			operations[o.DeviceResource] = o
+		}
+	}
+	if !found {
+		if _, ok := resources[commandName]; !ok {
+			return fmt.Errorf("command %s not found in profile %s", commandName, profile.Profile.Name)
+		}
+		operations[commandName] = dtos.ResourceOperation{DeviceResource: commandName}
+	}
+
+	for name, value := range settings {
+		operation, ok := operations[name]
+		if !ok {
+			var valid []string
+			for n := range operations {
+				valid = append(valid, n)
+			}
+			sort.Strings(valid)
+			return fmt.Errorf("resource %s is not part of command %s, expected one of %s", name, commandName, strings.Join(valid, ", "))
+		}
+		resource, ok := resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found in profile %s", name, profile.Profile.Name)
+		}
+		if !strings.Contains(resource.Properties.ReadWrite, common.ReadWrite_W) {
+			return fmt.Errorf("resource %s is read-only", name)
+		}
+		if isMappedWriteValue(operation, value) {
+			continue
+		}
+		if err := validateWriteValue(resource.Properties, value); err != nil {
+			return fmt.Errorf("invalid value for resource %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// isMappedWriteValue returns true if the value is one the resource operation maps to a device value
+func isMappedWriteValue(operation dtos.ResourceOperation, value string) bool {
+	for _, mapped := range operation.Mappings {
+		if mapped == value {
+			return true
+		}
+	}
+	return false
+}
+
+// validateWriteValue checks that the value is of the value type of the resource and
+// within its minimum and maximum
+func validateWriteValue(properties dtos.ResourceProperties, value string) error {
+	valueType := properties.ValueType
+	if strings.HasSuffix(valueType, "Array") {
+		var elements []jsonpkg.RawMessage
+		if err := jsonpkg.Unmarshal([]byte(value), &elements); err != nil {
+			return fmt.Errorf("%q is not a %s, expected a JSON array", value, valueType)
+		}
+		element := properties
+		element.ValueType = strings.TrimSuffix(valueType, "Array")
+		for _, e := range elements {
+			// string elements are JSON strings, other elements are used as they are
+			elementValue := string(e)
+			var s string
+			if err := jsonpkg.Unmarshal(e, &s); err == nil {
+				elementValue = s
+			}
+			if err := validateWriteValue(element, elementValue); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// compare compares the value to a minimum or maximum, returning false if the limit is invalid
+	var compare func(limit string) (int, bool)
+	var err error
+	switch valueType {
+	case common.ValueTypeString:
+		return nil
+	case common.ValueTypeBool:
+		_, err = strconv.ParseBool(value)
+	case common.ValueTypeObject:
+		if !jsonpkg.Valid([]byte(value)) {
+			err = errors.New("not valid JSON")
+		}
+	case common.ValueTypeBinary:
+		return errors.New("writing Binary values is not supported")
+	case common.ValueTypeUint8, common.ValueTypeUint16, common.ValueTypeUint32, common.ValueTypeUint64:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, getValueTypeBits(valueType))
+		compare = func(limit string) (int, bool) { return compareUint(n, limit) }
+	case common.ValueTypeInt8, common.ValueTypeInt16, common.ValueTypeInt32, common.ValueTypeInt64:
+		var n int64
+		n, err = strconv.ParseInt(value, 10, getValueTypeBits(valueType))
+		compare = func(limit string) (int, bool) { return compareInt(n, limit) }
+	case common.ValueTypeFloat32, common.ValueTypeFloat64:
+		var f float64
+		f, err = strconv.ParseFloat(value, getValueTypeBits(valueType))
+		compare = func(limit string) (int, bool) { return compareFloat(f, limit) }
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%q is not a %s", value, valueType)
+	}
+
+	if compare != nil && properties.Minimum != "" {
+		if c, ok := compare(properties.Minimum); ok && c < 0 {
+			return fmt.Errorf("%s is less than the minimum %s", value, properties.Minimum)
+		}
+	}
+	if compare != nil && properties.Maximum != "" {
+		if c, ok := compare(properties.Maximum); ok && c > 0 {
+			return fmt.Errorf("%s is greater than the maximum %s", value, properties.Maximum)
+		}
+	}
+	return nil
+}
+
+// compareInt compares an integer to a limit, exactly unless the limit is not an integer
+func compareInt(n int64, limit string) (int, bool) {
+	l, err := strconv.ParseInt(limit, 10, 64)
+	if err != nil {
+		return compareFloat(float64(n), limit)
+	}
+	switch {
+	case n < l:
+		return -1, true
+	case n > l:
+		return 1, true
+	}
+	return 0, true
+}
+
+// compareUint compares an unsigned integer to a limit, exactly unless the limit is not an integer
+func compareUint(n uint64, limit string) (int, bool) {
+	l, err := strconv.ParseUint(limit, 10, 64)
+	if err != nil {
+		if l, err := strconv.ParseInt(limit, 10, 64); err == nil && l < 0 {
+			return 1, true
+		}
+		return compareFloat(float64(n), limit)
+	}
+	switch {
+	case n < l:
+		return -1, true
+	case n > l:
+		return 1, true
+	}
+	return 0, true
+}
+
+// compareFloat compares a number to a limit
+func compareFloat(f float64, limit string) (int, bool) {
+	l, err := strconv.ParseFloat(limit, 64)
+	if err != nil {
+		return 0, false
+	}
+	switch {
+	case f < l:
+		return -1, true
+	case f > l:
+		return 1, true
+	}
+	return 0, true
+}
+
+// getValueTypeBits returns the size in bits of a numeric value type, e.g. 16 for Int16
+func getValueTypeBits(valueType string) int {
+	i := strings.IndexAny(valueType, "0123456789")
+	if i < 0 {
+		return 64
+	}
+	bits, err := strconv.Atoi(valueType[i:])
+	if err != nil {
+		return 64
+	}
+	return bits
+}
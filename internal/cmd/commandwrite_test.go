@@ -0,0 +1,72 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+)
+
+func TestValidateWriteValue(t *testing.T) {
+	properties := func(valueType string, min string, max string) dtos.ResourceProperties {
+		return dtos.ResourceProperties{ValueType: valueType, Minimum: min, Maximum: max}
+	}
+	tests := []struct {
+		name        string
+		properties  dtos.ResourceProperties
+		value       string
+		expectError bool
+	}{
+		{"string", properties("String", "", ""), "anything", false},
+		{"bool", properties("Bool", "", ""), "true", false},
+		{"invalid bool", properties("Bool", "", ""), "yes", true},
+		{"object", properties("Object", "", ""), `{"a": 1}`, false},
+		{"invalid object", properties("Object", "", ""), `{"a":`, true},
+		{"binary", properties("Binary", "", ""), "AAAA", true},
+		{"int8 in range", properties("Int8", "-10", "10"), "-10", false},
+		{"int8 overflow", properties("Int8", "", ""), "128", true},
+		{"int8 below minimum", properties("Int8", "-10", "10"), "-11", true},
+		{"uint8 negative", properties("Uint8", "", ""), "-1", true},
+		{"uint16 above maximum", properties("Uint16", "0", "1000"), "1001", true},
+		{"int64 maximum beyond float precision", properties("Int64", "", "9007199254740993"), "9007199254740993", false},
+		{"int64 above maximum beyond float precision", properties("Int64", "", "9007199254740992"), "9007199254740993", true},
+		{"uint64 above maximum beyond float precision", properties("Uint64", "", "18446744073709551614"), "18446744073709551615", true},
+		{"uint64 with negative minimum", properties("Uint64", "-5", ""), "0", false},
+		{"integer with decimal limit", properties("Int32", "0.5", ""), "0", true},
+		{"float in range", properties("Float32", "0", "1.5"), "1.25", false},
+		{"float above maximum", properties("Float64", "0", "1.5"), "1.75", true},
+		{"invalid float", properties("Float64", "", ""), "one", true},
+		{"invalid limit is ignored", properties("Int8", "low", "high"), "5", false},
+		{"int array", properties("Int16Array", "0", "100"), "[1, 2, 3]", false},
+		{"int array element above maximum", properties("Int16Array", "0", "100"), "[1, 200]", true},
+		{"bool array", properties("BoolArray", "", ""), "[true, false]", false},
+		{"invalid array", properties("Int16Array", "", ""), "1, 2", true},
+		{"escaped string elements are decoded", properties("BoolArray", "", ""), `["tru\u0065", "false"]`, false},
+		{"quoted numbers in an array", properties("Uint8Array", "", ""), `["1", "2"]`, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWriteValue(tt.properties, tt.value)
+			if tt.expectError && err == nil {
+				t.Error("expected an error")
+			} else if !tt.expectError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -18,6 +18,8 @@ package cmd
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
 	"strings"
 	"time"
 
@@ -131,18 +133,52 @@ func addStandardFlags(cmd *cobra.Command) {
 
 }
 
+// fetchAllPageSize is the number of items requested per page by fetchAll
+const fetchAllPageSize = 500
+
+// fetchAll calls page with increasing offsets and returns the items of all pages. page returns
+// the items of a page and the total count of items, or 0 if unknown. As pages are shorter than
+// requested when the limit exceeds the MaxResultCount of a service, only an empty page or
+// reaching the total count ends the paging.
+func fetchAll[T any](page func(offset int, limit int) ([]T, uint32, error)) ([]T, error) {
+	var items []T
+	for {
+		p, total, err := page(len(items), fetchAllPageSize)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, p...)
+		if len(p) == 0 || (total > 0 && len(items) >= int(total)) {
+			return items, nil
+		}
+	}
+}
+
 func getRFC822Time(t int64) string {
 	if t == 0 {
 		return "0"
 	} else {
-		return time.Unix(0, t*int64(time.Millisecond)).Format(time.RFC822)
+		return formatRFC822Time(time.Unix(0, t*int64(time.Millisecond)))
 	}
 }
 
-func getMillisTimestampFromRFC822Time(t string) (int64, error) {
-	result, err := time.Parse(time.RFC822, t)
+// sttyOn runs stty on the terminal of a file
+func sttyOn(f *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = f
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// getTerminalSize returns the number of rows and columns of the terminal of a file
+func getTerminalSize(f *os.File) (int, int, error) {
+	size, err := sttyOn(f, "size")
 	if err != nil {
-		return 0, err
+		return 0, 0, err
+	}
+	var rows, columns int
+	if _, err := fmt.Sscanf(size, "%d %d", &rows, &columns); err != nil || rows <= 0 || columns <= 0 {
+		return 0, 0, fmt.Errorf("invalid terminal size %q", size)
 	}
-	return result.UnixNano() / int64(time.Millisecond), nil
+	return rows, columns, nil
 }
@@ -0,0 +1,82 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestFetchAll(t *testing.T) {
+	tests := []struct {
+		name          string
+		total         int
+		maxResults    int
+		withTotal     bool
+		expectedPages int
+	}{
+		{"empty", 0, fetchAllPageSize, true, 1},
+		{"short page", 3, fetchAllPageSize, true, 1},
+		{"exactly one page", fetchAllPageSize, fetchAllPageSize, true, 1},
+		{"several pages", 2*fetchAllPageSize + 1, fetchAllPageSize, true, 3},
+		{"pages limited by the service", 250, 100, true, 3},
+		{"no total count", 3, fetchAllPageSize, false, 2},
+		{"pages limited by the service without total count", 250, 100, false, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pages := 0
+			items, err := fetchAll(func(offset int, limit int) ([]int, uint32, error) {
+				pages++
+				if limit > tt.maxResults {
+					limit = tt.maxResults
+				}
+				var page []int
+				for i := offset; i < tt.total && i < offset+limit; i++ {
+					page = append(page, i)
+				}
+				if !tt.withTotal {
+					return page, 0, nil
+				}
+				return page, uint32(tt.total), nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(items) != tt.total {
+				t.Errorf("expected %d items, got %d", tt.total, len(items))
+			}
+			for i, item := range items {
+				if item != i {
+					t.Fatalf("expected item %d at index %d, got %d", i, i, item)
+				}
+			}
+			if pages != tt.expectedPages {
+				t.Errorf("expected %d pages, got %d", tt.expectedPages, pages)
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		_, err := fetchAll(func(offset int, limit int) ([]int, uint32, error) {
+			return nil, 0, errors.New("failed")
+		})
+		if err == nil {
+			t.Error("expected an error")
+		}
+	})
+}
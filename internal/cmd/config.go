@@ -35,7 +35,8 @@ func init() {
 
 	rootCmd.AddCommand(cmd)
 	addStandardFlags(cmd)
-
+	initConfigDiffCommand(cmd)
+	initConfigGetCommand(cmd)
 }
 
 func handleConfig(cmd *cobra.Command, args []string) error {
@@ -49,13 +50,18 @@ func handleConfig(cmd *cobra.Command, args []string) error {
 			if xerr != nil {
 				return xerr
 			}
+			var result map[string]interface{}
+			jsonpkg.Unmarshal([]byte(jsonresult), &result)
+			result["config"] = maskSecrets(result["config"])
 
 			if json {
-				fmt.Println(string(jsonresult))
+				b, err := jsonpkg.Marshal(result)
+				if err != nil {
+					return err
+				}
+				fmt.Println(string(b))
 			} else {
 				fmt.Println(serviceName + ":")
-				var result map[string]interface{}
-				jsonpkg.Unmarshal([]byte(jsonresult), &result)
 				b, err := jsonpkg.MarshalIndent(result["config"], "", "    ")
 				if err != nil {
 					return err
@@ -0,0 +1,379 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/edgexfoundry/edgex-cli/internal/config"
+	"github.com/edgexfoundry/edgex-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+var configFrom, configTo string
+var configIgnore []string
+
+const configTargetHelp = `A target is the host or host:port of a deployment, the port defaulting to the service's one,
+or a snapshot file saved with e.g. 'edgex-cli config -d --json > core-data.json'. Snapshot paths must end
+with .json or be prefixed with file:, e.g. file:snapshots/core-data. Without a target, the configured
+service is used. Secrets are masked in snapshots unless saved with --show-secrets, and masked secrets
+aren't compared.`
+
+func initConfigDiffCommand(cmd *cobra.Command) {
+	var diffCmd = &cobra.Command{
+		Use:   "diff <service>",
+		Short: "Compare the configuration of a service across two targets",
+		Long: `Compare the configuration of a service across two targets, listing the keys which were added,
+removed or changed from the first target to the second. The command exits with an error when
+the configurations differ, so that it can be used to detect drift between gateways.
+
+` + configTargetHelp,
+		Example: `  edgex-cli config diff core-data --to gateway2
+  edgex-cli config diff core-metadata --from gateway1 --to gateway2:59881 --ignore Service.Host
+  edgex-cli config diff core-command --to core-command-snapshot.json`,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completeServiceKeys,
+		RunE:              handleConfigDiff,
+		SilenceUsage:      true,
+	}
+	diffCmd.Flags().StringVarP(&configFrom, "from", "", "", "The first target, by default the configured service")
+	diffCmd.Flags().StringVarP(&configTo, "to", "", "", "The second target")
+	diffCmd.Flags().StringArrayVarP(&configIgnore, "ignore", "", nil, "Ignore the keys under this dotted path, may be repeated")
+	diffCmd.MarkFlagRequired("to")
+	addFormatFlags(diffCmd)
+	cmd.AddCommand(diffCmd)
+}
+
+func initConfigGetCommand(cmd *cobra.Command) {
+	var getCmd = &cobra.Command{
+		Use:   "get <service> <dotted.path>",
+		Short: "Return a single value of the configuration of a service",
+		Long: `Return a single value of the configuration of a service, selected by a dotted path such as
+Writable.LogLevel. Keys are matched case-insensitively and array elements are selected by index.
+Objects and arrays are returned as JSON.
+
+` + configTargetHelp,
+		Example: `  edgex-cli config get core-data Writable.LogLevel
+  edgex-cli config get core-metadata Service --from gateway2`,
+		Args:              cobra.ExactArgs(2),
+		ValidArgsFunction: completeServiceKeys,
+		RunE:              handleConfigGet,
+		SilenceUsage:      true,
+	}
+	getCmd.Flags().StringVarP(&configFrom, "from", "", "", "The target, by default the configured service")
+	addFormatFlags(getCmd)
+	cmd.AddCommand(getCmd)
+}
+
+// completeServiceKeys completes the first argument with the names of the services
+func completeServiceKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var names []string
+	for name := range config.GetCoreServices() {
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// configChange is a key whose value differs between two configurations
+type configChange struct {
+	Key    string      `json:"key"`
+	Change string      `json:"change"`
+	From   interface{} `json:"from,omitempty"`
+	To     interface{} `json:"to,omitempty"`
+}
+
+func handleConfigDiff(cmd *cobra.Command, args []string) error {
+	from, err := getServiceConfig(args[0], configFrom)
+	if err != nil {
+		return err
+	}
+	to, err := getServiceConfig(args[0], configTo)
+	if err != nil {
+		return err
+	}
+	// Secrets are compared before being masked, so that changed secrets are listed
+	changes := diffConfigs(from, to)
+	for i, c := range changes {
+		if isSecretPath(strings.Split(c.Key, ".")) {
+			changes[i].From = maskSecret(c.From)
+			changes[i].To = maskSecret(c.To)
+		}
+	}
+
+	if json {
+		if changes == nil {
+			changes = []configChange{}
+		}
+		result, err := jsonpkg.Marshal(changes)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(result))
+	} else if len(changes) == 0 {
+		fmt.Println("No differences")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(w, "Change\tKey\tFrom\tTo")
+		for _, c := range changes {
+			from, to := formatConfigValue(c.From), formatConfigValue(c.To)
+			if c.Change == "added" {
+				from = ""
+			} else if c.Change == "removed" {
+				to = ""
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", c.Change, c.Key, from, to)
+		}
+		w.Flush()
+	}
+
+	if len(changes) > 0 {
+		return fmt.Errorf("the configurations differ by %d key(s)", len(changes))
+	}
+	return nil
+}
+
+func handleConfigGet(cmd *cobra.Command, args []string) error {
+	configuration, err := getServiceConfig(args[0], configFrom)
+	if err != nil {
+		return err
+	}
+	value, err := getConfigValue(configuration, args[1])
+	if err != nil {
+		return err
+	}
+	value = maskSecretsAt(strings.Split(args[1], "."), value)
+
+	if json {
+		result, err := jsonpkg.Marshal(value)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(result))
+		return nil
+	}
+	switch value.(type) {
+	case map[string]interface{}, []interface{}:
+		result, err := jsonpkg.MarshalIndent(value, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(result))
+	default:
+		fmt.Println(formatConfigValue(value))
+	}
+	return nil
+}
+
+// getServiceConfig returns the configuration of a service from a target, as decoded JSON
+func getServiceConfig(serviceName string, target string) (interface{}, error) {
+	s, ok := config.GetCoreServices()[serviceName]
+	if !ok {
+		var names []string
+		for name := range config.GetCoreServices() {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown service %q, expected one of %s", serviceName, strings.Join(names, ", "))
+	}
+
+	if isConfigSnapshot(target) {
+		return readConfigSnapshot(strings.TrimPrefix(target, "file:"), serviceName)
+	}
+	if target != "" {
+		if strings.Contains(target, ":") {
+			host, port, err := parseHostPort(target)
+			if err != nil {
+				return nil, err
+			}
+			s = service.Service{Host: host, Port: port}
+		} else {
+			s = service.Service{Host: target, Port: s.Port}
+		}
+	}
+
+	response, err := s.GetCommonClient().Configuration(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("could not get the configuration of %s from %s:%d: %v", serviceName, s.Host, s.Port, err)
+	}
+	return normalizeConfig(response.Config)
+}
+
+// isConfigSnapshot returns whether a target is a snapshot file rather than a host. Files are
+// recognized by their name only, so that a file named like a host doesn't change the target.
+func isConfigSnapshot(target string) bool {
+	return strings.HasSuffix(target, ".json") || strings.HasPrefix(target, "file:")
+}
+
+// readConfigSnapshot reads a configuration saved either as the response of the config endpoint
+// or as the configuration alone. The service of a response must be the given one.
+func readConfigSnapshot(path string, serviceName string) (interface{}, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot interface{}
+	if err := jsonpkg.Unmarshal(content, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %v", path, err)
+	}
+	if m, ok := snapshot.(map[string]interface{}); ok {
+		if c, ok := m["config"]; ok {
+			if _, ok := m["apiVersion"]; ok {
+				if name, ok := m["serviceName"].(string); ok && name != "" && name != serviceName {
+					return nil, fmt.Errorf("snapshot %s is the configuration of %s, not %s", path, name, serviceName)
+				}
+				return c, nil
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+// normalizeConfig converts a configuration to the types of decoded JSON
+func normalizeConfig(configuration interface{}) (interface{}, error) {
+	b, err := jsonpkg.Marshal(configuration)
+	if err != nil {
+		return nil, err
+	}
+	var result interface{}
+	err = jsonpkg.Unmarshal(b, &result)
+	return result, err
+}
+
+// flattenConfig maps the dotted path of each value of a configuration to the value.
+// Empty objects and arrays are kept as values so that they aren't lost.
+func flattenConfig(prefix string, value interface{}, result map[string]interface{}) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if len(v) == 0 && prefix != "" {
+			result[prefix] = v
+		}
+		for key, child := range v {
+			flattenConfig(join(key), child, result)
+		}
+	case []interface{}:
+		if len(v) == 0 && prefix != "" {
+			result[prefix] = v
+		}
+		for i, child := range v {
+			flattenConfig(join(strconv.Itoa(i)), child, result)
+		}
+	default:
+		result[prefix] = v
+	}
+}
+
+// diffConfigs returns the changes from one configuration to another, sorted by key
+func diffConfigs(from interface{}, to interface{}) []configChange {
+	fromValues := make(map[string]interface{})
+	toValues := make(map[string]interface{})
+	flattenConfig("", from, fromValues)
+	flattenConfig("", to, toValues)
+
+	var changes []configChange
+	for key, fromValue := range fromValues {
+		if isIgnoredConfigKey(key) {
+			continue
+		}
+		toValue, ok := toValues[key]
+		if !ok {
+			changes = append(changes, configChange{Key: key, Change: "removed", From: fromValue})
+		} else if fromValue != maskedSecret && toValue != maskedSecret && formatConfigValue(fromValue) != formatConfigValue(toValue) {
+			changes = append(changes, configChange{Key: key, Change: "changed", From: fromValue, To: toValue})
+		}
+	}
+	for key, toValue := range toValues {
+		if _, ok := fromValues[key]; !ok && !isIgnoredConfigKey(key) {
+			changes = append(changes, configChange{Key: key, Change: "added", To: toValue})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Key < changes[j].Key })
+	return changes
+}
+
+func isIgnoredConfigKey(key string) bool {
+	for _, ignored := range configIgnore {
+		if strings.EqualFold(key, ignored) || strings.HasPrefix(strings.ToLower(key), strings.ToLower(ignored)+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// getConfigValue returns the value at a dotted path of a configuration
+func getConfigValue(configuration interface{}, path string) (interface{}, error) {
+	value := configuration
+	var walked []string
+	for _, key := range strings.Split(path, ".") {
+		walked = append(walked, key)
+		switch v := value.(type) {
+		case map[string]interface{}:
+			child, ok := v[key]
+			if !ok {
+				for k, c := range v {
+					if strings.EqualFold(k, key) {
+						child, ok = c, true
+						break
+					}
+				}
+			}
+			if !ok {
+				return nil, fmt.Errorf("%s not found in the configuration", strings.Join(walked, "."))
+			}
+			value = child
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, fmt.Errorf("%s is not an index of the %d elements of %s", key, len(v), strings.Join(walked[:len(walked)-1], "."))
+			}
+			value = v[i]
+		default:
+			return nil, fmt.Errorf("%s is not an object or an array", strings.Join(walked[:len(walked)-1], "."))
+		}
+	}
+	return value, nil
+}
+
+// formatConfigValue formats a configuration value, strings without quotes and others as JSON
+func formatConfigValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	b, err := jsonpkg.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(b)
+}
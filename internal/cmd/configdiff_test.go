@@ -0,0 +1,195 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFlattenConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   interface{}
+		expected map[string]interface{}
+	}{
+		{"empty", map[string]interface{}{}, map[string]interface{}{}},
+		{"values", map[string]interface{}{"LogLevel": "INFO", "Port": 59880.0, "Enabled": true, "Nil": nil},
+			map[string]interface{}{"LogLevel": "INFO", "Port": 59880.0, "Enabled": true, "Nil": nil}},
+		{"nested objects", map[string]interface{}{"Writable": map[string]interface{}{"Telemetry": map[string]interface{}{"Interval": "30s"}}},
+			map[string]interface{}{"Writable.Telemetry.Interval": "30s"}},
+		{"arrays", map[string]interface{}{"Tags": []interface{}{"a", map[string]interface{}{"b": 1.0}}},
+			map[string]interface{}{"Tags.0": "a", "Tags.1.b": 1.0}},
+		{"empty object and array", map[string]interface{}{"Empty": map[string]interface{}{}, "None": []interface{}{}},
+			map[string]interface{}{"Empty": map[string]interface{}{}, "None": []interface{}{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := make(map[string]interface{})
+			flattenConfig("", tt.config, result)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestDiffConfigs(t *testing.T) {
+	from := map[string]interface{}{
+		"Service":  map[string]interface{}{"Host": "gateway1", "Port": 59880.0},
+		"Writable": map[string]interface{}{"LogLevel": "INFO", "InsecureSecrets": map[string]interface{}{"Password": maskedSecret}},
+		"Tags":     []interface{}{"a", "b"},
+		"Removed":  true,
+	}
+	to := map[string]interface{}{
+		"Service":  map[string]interface{}{"Host": "gateway2", "Port": 59880.0},
+		"Writable": map[string]interface{}{"LogLevel": "DEBUG", "InsecureSecrets": map[string]interface{}{"Password": "secret"}},
+		"Tags":     []interface{}{"a"},
+		"Added":    "new",
+	}
+	tests := []struct {
+		name     string
+		from     interface{}
+		to       interface{}
+		ignore   []string
+		expected []configChange
+	}{
+		{"same configurations", from, from, nil, nil},
+		{"changes", from, to, nil, []configChange{
+			{Key: "Added", Change: "added", To: "new"},
+			{Key: "Removed", Change: "removed", From: true},
+			{Key: "Service.Host", Change: "changed", From: "gateway1", To: "gateway2"},
+			{Key: "Tags.1", Change: "removed", From: "b"},
+			{Key: "Writable.LogLevel", Change: "changed", From: "INFO", To: "DEBUG"},
+		}},
+		{"ignored keys", from, to, []string{"service", "Writable.LogLevel", "Tag"}, []configChange{
+			{Key: "Added", Change: "added", To: "new"},
+			{Key: "Removed", Change: "removed", From: true},
+			{Key: "Tags.1", Change: "removed", From: "b"},
+		}},
+		{"numbers compared to strings as text", map[string]interface{}{"Port": 59880.0}, map[string]interface{}{"Port": "59880"}, nil, nil},
+	}
+	defer func() { configIgnore = nil }()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configIgnore = tt.ignore
+			changes := diffConfigs(tt.from, tt.to)
+			if !reflect.DeepEqual(changes, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, changes)
+			}
+		})
+	}
+}
+
+func TestGetConfigValue(t *testing.T) {
+	config := map[string]interface{}{
+		"Writable": map[string]interface{}{"LogLevel": "INFO"},
+		"Tags":     []interface{}{"a", map[string]interface{}{"Name": "b"}},
+	}
+	tests := []struct {
+		name        string
+		path        string
+		expected    interface{}
+		expectError bool
+	}{
+		{"value", "Writable.LogLevel", "INFO", false},
+		{"case-insensitive keys", "writable.loglevel", "INFO", false},
+		{"object", "Writable", map[string]interface{}{"LogLevel": "INFO"}, false},
+		{"array element", "Tags.0", "a", false},
+		{"key of array element", "Tags.1.Name", "b", false},
+		{"missing key", "Writable.Missing", nil, true},
+		{"index out of range", "Tags.2", nil, true},
+		{"invalid index", "Tags.first", nil, true},
+		{"key of value", "Writable.LogLevel.Level", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, err := getConfigValue(config, tt.path)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %v", value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(value, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, value)
+			}
+		})
+	}
+}
+
+func TestIsConfigSnapshot(t *testing.T) {
+	tests := []struct {
+		target   string
+		expected bool
+	}{
+		{"", false},
+		{"gateway2", false},
+		{"gateway2:59880", false},
+		{"core-data.json", true},
+		{"snapshots/core-data.json", true},
+		{"file:core-data", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			if result := isConfigSnapshot(tt.target); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestReadConfigSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expected    interface{}
+		expectError bool
+	}{
+		{"response", `{"apiVersion":"v2","config":{"LogLevel":"INFO"},"serviceName":"core-data"}`, map[string]interface{}{"LogLevel": "INFO"}, false},
+		{"response without service", `{"apiVersion":"v2","config":{"LogLevel":"INFO"},"serviceName":""}`, map[string]interface{}{"LogLevel": "INFO"}, false},
+		{"configuration", `{"LogLevel":"INFO"}`, map[string]interface{}{"LogLevel": "INFO"}, false},
+		{"other service", `{"apiVersion":"v2","config":{"LogLevel":"INFO"},"serviceName":"core-metadata"}`, nil, true},
+		{"invalid JSON", `{"LogLevel":`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "snapshot.json")
+			if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			config, err := readConfigSnapshot(path, "core-data")
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %v", config)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(config, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, config)
+			}
+		})
+	}
+}
@@ -0,0 +1,698 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/edgexfoundry/edgex-cli/internal/config"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	dtosCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/spf13/cobra"
+)
+
+var dashboardInterval, dashboardTimeout time.Duration
+
+// The number of events and notifications fetched at each refresh, all devices are fetched
+const dashboardEventLimit = 20
+const dashboardNotificationLimit = 20
+
+func init() {
+	var cmd = &cobra.Command{
+		Use:   "dashboard",
+		Short: "Show a live view of the EdgeX deployment",
+		Long: `Show a full-screen view of the EdgeX deployment, refreshed periodically, with the health, version and
+metrics of the services, the devices with their operating state and last reported times, and the
+most recent events and notifications.
+
+Keys: up/down (or k/j) select a device, Enter shows its commands, r refreshes, q quits.
+In the device view, up/down select a read command, Enter issues it and Esc goes back.`,
+		RunE:         handleDashboard,
+		SilenceUsage: true,
+	}
+	cmd.Flags().DurationVarP(&dashboardInterval, "interval", "i", 5*time.Second, "Time between refreshes")
+	cmd.Flags().DurationVarP(&dashboardTimeout, "timeout", "t", 5*time.Second, "How long to wait for each service")
+	rootCmd.AddCommand(cmd)
+}
+
+// dashboardService is the state of a service shown by the dashboard
+type dashboardService struct {
+	name    string
+	err     string
+	latency time.Duration
+	version string
+	metrics dtosCommon.Metrics
+}
+
+// dashboardSnapshot is the state of the deployment at a refresh
+type dashboardSnapshot struct {
+	fetched          time.Time
+	services         []dashboardService
+	devices          []dtos.Device
+	devicesErr       string
+	events           []dtos.Event
+	eventsErr        string
+	notifications    []dtos.Notification
+	notificationsErr string
+}
+
+// dashboard is the state of the user interface. It's only accessed by the goroutine running the
+// dashboard, other goroutines send it updates as functions.
+type dashboard struct {
+	rows     int
+	columns  int
+	snapshot dashboardSnapshot
+	pending  int // the number of fetches of the refresh in progress
+	selected int
+
+	// The device view
+	device          *dtos.Device
+	commands        []dtos.CoreCommand
+	commandsErr     string
+	selectedCommand int
+	reading         bool
+	readResult      []string
+	readErr         string
+}
+
+func handleDashboard(cmd *cobra.Command, args []string) error {
+	if dashboardInterval <= 0 {
+		return errors.New("the interval must be greater than zero")
+	}
+	if !isTerminal(os.Stdin) {
+		return errors.New("the dashboard requires a terminal")
+	}
+	state, err := stty("-g")
+	if err != nil {
+		return err
+	}
+	// Reads return at least every tenth of a second so that the key reader can stop
+	if _, err := stty("-icanon", "-echo", "-isig", "min", "0", "time", "1"); err != nil {
+		return err
+	}
+	out := bufio.NewWriter(os.Stdout)
+	fmt.Fprint(out, "\x1b[?1049h\x1b[?25l")
+	out.Flush()
+	defer func() {
+		fmt.Fprint(out, "\x1b[?25h\x1b[?1049l")
+		out.Flush()
+		stty(state)
+	}()
+
+	keys := make(chan string)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go readDashboardKeys(keys, stop, done)
+	// Wait for the key reader before the terminal mode is restored, otherwise it could block
+	// in a read and take the next key pressed, e.g. in the shell
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	resized := make(chan os.Signal, 1)
+	notifyResize(resized)
+	defer signal.Stop(resized)
+
+	updates := make(chan func(*dashboard), 8)
+	ticker := time.NewTicker(dashboardInterval)
+	defer ticker.Stop()
+
+	d := &dashboard{}
+	d.updateSize()
+	d.refresh(updates)
+	for {
+		d.render(out)
+		select {
+		case update := <-updates:
+			update(d)
+		case <-ticker.C:
+			d.refresh(updates)
+		case <-resized:
+			d.updateSize()
+		case key := <-keys:
+			if key == "q" || key == "ctrl-c" {
+				return nil
+			}
+			d.handleKey(key, updates)
+		}
+	}
+}
+
+// readDashboardKeys sends the keys pressed until stopped, then closes done
+func readDashboardKeys(keys chan<- string, stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+	buffer := make([]byte, 64)
+	for {
+		select {
+		case <-stop:
+			return
+		default:
+		}
+		n, err := os.Stdin.Read(buffer)
+		if err != nil || n == 0 {
+			continue
+		}
+		for _, key := range parseDashboardKeys(buffer[:n]) {
+			select {
+			case keys <- key:
+			case <-stop:
+				return
+			}
+		}
+	}
+}
+
+// parseDashboardKeys returns the names of the keys in the input read from the terminal
+func parseDashboardKeys(input []byte) []string {
+	var keys []string
+	for i := 0; i < len(input); i++ {
+		switch c := input[i]; {
+		case c == 27 && i+2 < len(input) && (input[i+1] == '[' || input[i+1] == 'O'):
+			switch input[i+2] {
+			case 'A':
+				keys = append(keys, "up")
+			case 'B':
+				keys = append(keys, "down")
+			case 'C':
+				keys = append(keys, "right")
+			case 'D':
+				keys = append(keys, "left")
+			}
+			i += 2
+			// Skip the parameters of longer sequences, e.g. Page Up
+			for i < len(input) && !(input[i] >= '@' && input[i] <= '~') {
+				i++
+			}
+		case c == 27:
+			keys = append(keys, "esc")
+		case c == 3:
+			keys = append(keys, "ctrl-c")
+		case c == '\r' || c == '\n':
+			keys = append(keys, "enter")
+		case c == 127 || c == 8:
+			keys = append(keys, "backspace")
+		default:
+			keys = append(keys, string(c))
+		}
+	}
+	return keys
+}
+
+func (d *dashboard) handleKey(key string, updates chan<- func(*dashboard)) {
+	if d.device == nil {
+		switch key {
+		case "up", "k":
+			if d.selected > 0 {
+				d.selected--
+			}
+		case "down", "j":
+			if d.selected < len(d.snapshot.devices)-1 {
+				d.selected++
+			}
+		case "enter", "right":
+			if d.selected < len(d.snapshot.devices) {
+				device := d.snapshot.devices[d.selected]
+				d.openDevice(&device, updates)
+			}
+		case "r":
+			d.refresh(updates)
+		}
+		return
+	}
+
+	switch key {
+	case "up", "k":
+		if d.selectedCommand > 0 {
+			d.selectedCommand--
+		}
+	case "down", "j":
+		if d.selectedCommand < len(d.commands)-1 {
+			d.selectedCommand++
+		}
+	case "enter", "r":
+		d.readCommand(updates)
+	case "esc", "backspace", "left":
+		d.device = nil
+	}
+}
+
+// withDashboardTimeout runs fetch in the background, giving up after the dashboard timeout.
+// The clients don't cancel requests when their context is done, so a request which times out
+// is left to complete in the background and its result is discarded.
+func withDashboardTimeout(fetch func() func(*dashboard), failed func(error) func(*dashboard)) func(*dashboard) {
+	done := make(chan func(*dashboard), 1)
+	go func() {
+		done <- fetch()
+	}()
+	select {
+	case update := <-done:
+		return update
+	case <-time.After(dashboardTimeout):
+		return failed(fmt.Errorf("timed out after %v", dashboardTimeout))
+	}
+}
+
+// refresh fetches the state of the services, devices, events and notifications concurrently,
+// unless a refresh is in progress. Each pane is updated as soon as its state is fetched.
+func (d *dashboard) refresh(updates chan<- func(*dashboard)) {
+	if d.pending > 0 {
+		return
+	}
+	services := config.GetCoreServices()
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(d.snapshot.services) != len(names) {
+		d.snapshot.services = make([]dashboardService, len(names))
+	}
+
+	fetches := []func() func(*dashboard){fetchDashboardDevices, fetchDashboardEvents, fetchDashboardNotifications}
+	for i, name := range names {
+		i, name := i, name
+		fetches = append(fetches, func() func(*dashboard) {
+			return fetchDashboardService(i, name)
+		})
+	}
+	d.pending = len(fetches)
+	for _, fetch := range fetches {
+		fetch := fetch
+		go func() {
+			update := fetch()
+			updates <- func(d *dashboard) {
+				update(d)
+				d.pending--
+				if d.pending == 0 {
+					d.snapshot.fetched = time.Now()
+				}
+			}
+		}()
+	}
+}
+
+func fetchDashboardService(i int, name string) func(*dashboard) {
+	return withDashboardTimeout(func() func(*dashboard) {
+		client := config.GetCoreService(name).GetCommonClient()
+		service := dashboardService{name: name}
+		started := time.Now()
+		if _, err := client.Ping(context.Background()); err != nil {
+			service.err = err.Error()
+			return func(d *dashboard) { d.snapshot.services[i] = service }
+		}
+		service.latency = time.Since(started)
+		if response, err := client.Version(context.Background()); err == nil {
+			service.version = response.Version
+		}
+		if response, err := client.Metrics(context.Background()); err == nil {
+			service.metrics = response.Metrics
+		}
+		return func(d *dashboard) { d.snapshot.services[i] = service }
+	}, func(err error) func(*dashboard) {
+		return func(d *dashboard) { d.snapshot.services[i] = dashboardService{name: name, err: err.Error()} }
+	})
+}
+
+func fetchDashboardDevices() func(*dashboard) {
+	failed := func(err error) func(*dashboard) {
+		return func(d *dashboard) { d.snapshot.devicesErr = err.Error() }
+	}
+	return withDashboardTimeout(func() func(*dashboard) {
+		client := getCoreMetaDataService().GetDeviceClient()
+		devices, err := fetchAll(func(offset int, limit int) ([]dtos.Device, uint32, error) {
+			response, err := client.AllDevices(context.Background(), nil, offset, limit)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Devices, response.TotalCount, nil
+		})
+		if err != nil {
+			return failed(err)
+		}
+		sort.Slice(devices, func(i, j int) bool { return devices[i].Name < devices[j].Name })
+		return func(d *dashboard) {
+			d.snapshot.devices = devices
+			d.snapshot.devicesErr = ""
+			if d.selected >= len(devices) {
+				d.selected = len(devices) - 1
+			}
+			if d.selected < 0 {
+				d.selected = 0
+			}
+		}
+	}, failed)
+}
+
+func fetchDashboardEvents() func(*dashboard) {
+	failed := func(err error) func(*dashboard) {
+		return func(d *dashboard) { d.snapshot.eventsErr = err.Error() }
+	}
+	return withDashboardTimeout(func() func(*dashboard) {
+		client := getCoreDataService().GetEventClient()
+		response, err := client.AllEvents(context.Background(), 0, dashboardEventLimit)
+		if err != nil {
+			return failed(err)
+		}
+		return func(d *dashboard) {
+			d.snapshot.events = response.Events
+			d.snapshot.eventsErr = ""
+		}
+	}, failed)
+}
+
+func fetchDashboardNotifications() func(*dashboard) {
+	failed := func(err error) func(*dashboard) {
+		return func(d *dashboard) { d.snapshot.notificationsErr = err.Error() }
+	}
+	return withDashboardTimeout(func() func(*dashboard) {
+		client := getSupportNotificationsService().GetNotificationClient()
+		end := int(time.Now().UnixNano() / int64(time.Millisecond))
+		response, err := client.NotificationsByTimeRange(context.Background(), 0, end, 0, dashboardNotificationLimit)
+		if err != nil {
+			return failed(err)
+		}
+		return func(d *dashboard) {
+			d.snapshot.notifications = response.Notifications
+			d.snapshot.notificationsErr = ""
+		}
+	}, failed)
+}
+
+// openDevice shows the device view of a device, fetching its commands in the background
+func (d *dashboard) openDevice(device *dtos.Device, updates chan<- func(*dashboard)) {
+	d.device = device
+	d.commands = nil
+	d.commandsErr = ""
+	d.selectedCommand = 0
+	d.readResult = nil
+	d.readErr = ""
+	name := device.Name
+	failed := func(err error) func(*dashboard) {
+		return func(d *dashboard) {
+			if d.device != nil && d.device.Name == name {
+				d.commandsErr = err.Error()
+			}
+		}
+	}
+	go func() {
+		updates <- withDashboardTimeout(func() func(*dashboard) {
+			client := getCoreCommandService().GetCommandClient()
+			response, err := client.DeviceCoreCommandsByDeviceName(context.Background(), name)
+			if err != nil {
+				return failed(err)
+			}
+			var commands []dtos.CoreCommand
+			for _, c := range response.DeviceCoreCommand.CoreCommands {
+				if c.Get {
+					commands = append(commands, c)
+				}
+			}
+			return func(d *dashboard) {
+				if d.device != nil && d.device.Name == name {
+					d.commands = commands
+				}
+			}
+		}, failed)
+	}()
+}
+
+// readCommand issues the selected read command in the background
+func (d *dashboard) readCommand(updates chan<- func(*dashboard)) {
+	if d.reading || d.selectedCommand >= len(d.commands) {
+		return
+	}
+	d.reading = true
+	d.readResult = nil
+	d.readErr = ""
+	device, command := d.device.Name, d.commands[d.selectedCommand].Name
+	failed := func(err error) func(*dashboard) {
+		return func(d *dashboard) {
+			d.reading = false
+			d.readErr = err.Error()
+		}
+	}
+	go func() {
+		updates <- withDashboardTimeout(func() func(*dashboard) {
+			client := getCoreCommandService().GetCommandClient()
+			started := time.Now()
+			response, err := client.IssueGetCommandByName(context.Background(), device, command, "no", "yes")
+			if err != nil {
+				return failed(err)
+			}
+			latency := time.Since(started).Round(time.Millisecond)
+			result := []string{fmt.Sprintf("%s %s at %s in %v", device, command, time.Now().In(timeLocation).Format("15:04:05"), latency)}
+			if response != nil {
+				event, err := getTypedEvent(response.Event)
+				if err != nil {
+					return failed(err)
+				}
+				for _, r := range event.Readings {
+					result = append(result, fmt.Sprintf("  %s = %s (%s)", r.ResourceName, formatTypedReadingValue(r), r.ValueType))
+				}
+			}
+			return func(d *dashboard) {
+				d.reading = false
+				d.readResult = result
+			}
+		}, failed)
+	}()
+}
+
+// formatDashboardTable formats rows of tab separated cells as aligned lines
+func formatDashboardTable(rows []string) []string {
+	var buffer bytes.Buffer
+	w := tabwriter.NewWriter(&buffer, 1, 1, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(w, row)
+	}
+	w.Flush()
+	return strings.Split(strings.TrimSuffix(buffer.String(), "\n"), "\n")
+}
+
+// formatLastReported formats the time a device last reported, or - if it never reported
+func formatLastReported(t int64) string {
+	if t <= 0 {
+		return "-"
+	}
+	return formatRFC822Time(getLastReportedTime(t))
+}
+
+const (
+	ansiReverse = "\x1b[7m"
+	ansiBold    = "\x1b[1m"
+	ansiRed     = "\x1b[31m"
+	ansiGreen   = "\x1b[32m"
+	ansiReset   = "\x1b[0m"
+)
+
+// dashboardScreen accumulates the lines of a screen, truncated to its width
+type dashboardScreen struct {
+	lines []string
+	width int
+}
+
+func (s *dashboardScreen) add(line string, style string) {
+	runes := []rune(line)
+	if len(runes) > s.width {
+		runes = runes[:s.width]
+	}
+	line = string(runes)
+	if style != "" {
+		line = style + line + strings.Repeat(" ", s.width-len(runes)) + ansiReset
+	}
+	s.lines = append(s.lines, line)
+}
+
+// addPane adds a titled pane showing at most height lines of a table, scrolled so that the
+// selected row, if any, is visible
+func (s *dashboardScreen) addPane(title string, errMessage string, table []string, selected int, height int) {
+	s.add(title, ansiBold)
+	height--
+	if errMessage != "" {
+		s.add("ERROR: "+errMessage, ansiRed)
+		height--
+	}
+	if len(table) == 0 || height <= 0 {
+		return
+	}
+	s.add(table[0], "")
+	rows := table[1:]
+	height--
+	first := 0
+	if selected >= height {
+		first = selected - height + 1
+	}
+	for i := first; i < len(rows) && i < first+height; i++ {
+		style := ""
+		if i == selected {
+			style = ansiReverse
+		}
+		s.add(rows[i], style)
+	}
+}
+
+// updateSize gets the size of the terminal, which is only done at start and when it's resized
+// as it runs stty
+func (d *dashboard) updateSize() {
+	rows, columns, err := getTerminalSize(os.Stdin)
+	if err != nil {
+		rows, columns = 24, 80
+	}
+	d.rows, d.columns = rows, columns
+}
+
+func (d *dashboard) render(out *bufio.Writer) {
+	rows, columns := d.rows, d.columns
+	screen := &dashboardScreen{width: columns}
+
+	status := "refreshed " + formatRFC822Time(d.snapshot.fetched)
+	if d.snapshot.fetched.IsZero() {
+		status = "loading"
+	}
+	if d.pending > 0 && !d.snapshot.fetched.IsZero() {
+		status = "refreshing"
+	}
+	screen.add(fmt.Sprintf("EdgeX dashboard - %s - every %v", status, dashboardInterval), ansiReverse)
+
+	table := []string{"Service\tStatus\tVersion\tLatency\tCpuBusyAvg\tMemAlloc\tMemSys"}
+	for _, service := range d.snapshot.services {
+		switch {
+		case service.name == "":
+			continue
+		case service.err != "":
+			table = append(table, fmt.Sprintf("%s\tDOWN\t%s", service.name, service.err))
+		default:
+			table = append(table, fmt.Sprintf("%s\tUP\t%s\t%v\t%v%%\t%v\t%v", service.name, service.version,
+				service.latency.Round(time.Millisecond), service.metrics.CpuBusyAvg, service.metrics.MemAlloc, service.metrics.MemSys))
+		}
+	}
+	for i, line := range formatDashboardTable(table) {
+		style := ""
+		if i == 0 {
+			style = ansiBold
+		} else if strings.Contains(line, " DOWN ") {
+			style = ansiRed
+		}
+		screen.add(line, style)
+	}
+
+	// Keep two lines for the help
+	available := rows - len(screen.lines) - 2
+	if d.device == nil {
+		d.renderOverview(screen, available)
+		screen.add("", "")
+		screen.add("up/down select device  Enter open device  r refresh  q quit", ansiReverse)
+	} else {
+		d.renderDevice(screen, available)
+		screen.add("", "")
+		screen.add("up/down select command  Enter read  Esc back  q quit", ansiReverse)
+	}
+
+	fmt.Fprint(out, "\x1b[H")
+	for i, line := range screen.lines {
+		if i >= rows {
+			break
+		}
+		if i > 0 {
+			fmt.Fprint(out, "\r\n")
+		}
+		fmt.Fprint(out, line, "\x1b[K")
+	}
+	fmt.Fprint(out, "\x1b[J")
+	out.Flush()
+}
+
+func (d *dashboard) renderOverview(screen *dashboardScreen, available int) {
+	devices := []string{"Name\tProfile\tService\tAdminState\tOperatingState\tLastReported"}
+	for _, device := range d.snapshot.devices {
+		devices = append(devices, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s", device.Name, device.ProfileName, device.ServiceName,
+			device.AdminState, device.OperatingState, formatLastReported(device.LastReported)))
+	}
+	events := []string{"Origin\tDevice\tSource\tReadings"}
+	for _, event := range d.snapshot.events {
+		events = append(events, fmt.Sprintf("%s\t%s\t%s\t%d", formatRFC822Time(time.Unix(0, event.Origin)),
+			event.DeviceName, event.SourceName, len(event.Readings)))
+	}
+	notifications := []string{"Created\tCategory\tSeverity\tStatus\tContent"}
+	for _, n := range d.snapshot.notifications {
+		notifications = append(notifications, fmt.Sprintf("%s\t%s\t%s\t%s\t%s", formatRFC822Time(time.Unix(0, n.Created*int64(time.Millisecond))),
+			n.Category, n.Severity, n.Status, strings.ReplaceAll(n.Content, "\n", " ")))
+	}
+
+	// The devices get half of the space, the events and notifications a quarter each
+	deviceHeight := available / 2
+	eventHeight := (available - deviceHeight) / 2
+	screen.addPane(fmt.Sprintf("DEVICES (%d)", len(d.snapshot.devices)), d.snapshot.devicesErr, formatDashboardTable(devices), d.selected, deviceHeight)
+	screen.addPane("RECENT EVENTS", d.snapshot.eventsErr, formatDashboardTable(events), -1, eventHeight)
+	screen.addPane("RECENT NOTIFICATIONS", d.snapshot.notificationsErr, formatDashboardTable(notifications), -1, available-deviceHeight-eventHeight)
+}
+
+func (d *dashboard) renderDevice(screen *dashboardScreen, available int) {
+	device := d.device
+	details := formatDashboardTable([]string{
+		"Name\t" + device.Name,
+		"Description\t" + device.Description,
+		"Profile\t" + device.ProfileName,
+		"Service\t" + device.ServiceName,
+		"AdminState\t" + device.AdminState,
+		"OperatingState\t" + device.OperatingState,
+		"LastReported\t" + formatLastReported(device.LastReported),
+		"Labels\t" + strings.Join(device.Labels, ","),
+	})
+	screen.add("DEVICE "+device.Name, ansiBold)
+	for _, line := range details {
+		screen.add(line, "")
+	}
+	screen.add("", "")
+	available -= len(details) + 2
+
+	commands := []string{"Command\tPath"}
+	for _, c := range d.commands {
+		commands = append(commands, c.Name+"\t"+c.Path)
+	}
+	if d.commands == nil && d.commandsErr == "" {
+		commands = []string{"Loading..."}
+	}
+	commandHeight := available / 2
+	screen.addPane("READ COMMANDS", d.commandsErr, formatDashboardTable(commands), d.selectedCommand, commandHeight)
+	screen.add("", "")
+
+	screen.add("READ RESULT", ansiBold)
+	switch {
+	case d.reading:
+		screen.add("Reading...", "")
+	case d.readErr != "":
+		screen.add("ERROR: "+d.readErr, ansiRed)
+	}
+	for i, line := range d.readResult {
+		if i >= available-commandHeight-2 {
+			break
+		}
+		screen.add(line, "")
+	}
+}
@@ -21,8 +21,6 @@ import (
 	jsonpkg "encoding/json"
 	"errors"
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
@@ -77,6 +75,7 @@ func initListDeviceCommand(cmd *cobra.Command) {
 	}
 
 	addFormatFlags(listCmd)
+	addTableFlags(listCmd)
 	addVerboseFlag(listCmd)
 	addLimitOffsetFlags(listCmd)
 	addLabelsFlag(listCmd)
@@ -159,6 +158,7 @@ func initGetDeviceByNameCommand(cmd *cobra.Command) {
 	nameCmd.Flags().StringVarP(&deviceName, "name", "n", "", "Device name")
 	nameCmd.MarkFlagRequired("name")
 	addFormatFlags(nameCmd)
+	addTableFlags(nameCmd)
 	addVerboseFlag(nameCmd)
 	cmd.AddCommand(nameCmd)
 
@@ -245,6 +245,7 @@ func handleGetDeviceByName(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	response.Device.Protocols = maskProtocols(response.Device.Protocols)
 
 	if json {
 		result, err := jsonpkg.Marshal(response)
@@ -254,10 +255,7 @@ func handleGetDeviceByName(cmd *cobra.Command, args []string) error {
 
 		fmt.Println(string(result))
 	} else {
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printDeviceTableHeader(w)
-		printDevice(w, &response.Device)
-		w.Flush()
+		return printRows(deviceTable, []dtos.Device{response.Device})
 	}
 	return nil
 }
@@ -308,6 +306,7 @@ func handleListDevices(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	maskDeviceSecrets(response.Devices)
 	if json {
 		result, err := jsonpkg.Marshal(response)
 		if err != nil {
@@ -321,12 +320,7 @@ func handleListDevices(cmd *cobra.Command, args []string) error {
 			fmt.Println("No devices available")
 			return nil
 		}
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printDeviceTableHeader(w)
-		for _, device := range response.Devices {
-			printDevice(w, &device)
-		}
-		w.Flush()
+		return printRows(deviceTable, response.Devices)
 	}
 	return nil
 }
@@ -343,38 +337,22 @@ func getDeviceAttributes() (protocols map[string]dtos.ProtocolProperties, labels
 	return
 }
 
-func printDeviceTableHeader(w *tabwriter.Writer) {
-	if verbose {
-		fmt.Fprintln(w, "Id\tName\tDescription\tServiceName\tProfileName\tAdminState\tOperatingState\tLastReported\tLastConnected\tLabels\tLocation\tAutoEvents\tProtocols")
-	} else {
-		fmt.Fprintln(w, "Name\tDescription\tServiceName\tProfileName\tLabels\tAutoEvents")
-	}
-
-}
-
-func printDevice(w *tabwriter.Writer, d *dtos.Device) {
-	if verbose {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			d.Id,
-			d.Name,
-			d.Description,
-			d.ServiceName,
-			d.ProfileName,
-			d.AdminState,
-			d.OperatingState,
-			getRFC822Time(d.LastReported),
-			getRFC822Time(d.LastConnected),
-			d.Labels,
-			d.Location,
-			d.AutoEvents,
-			d.Protocols)
-	} else {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n",
-			d.Name,
-			d.Description,
-			d.ServiceName,
-			d.ProfileName,
-			d.Labels,
-			d.AutoEvents)
-	}
+// deviceTable defines the columns of tables of devices
+var deviceTable = table{
+	columns: []column{
+		newColumn("Id", func(d *dtos.Device) interface{} { return d.Id }),
+		newColumn("Name", func(d *dtos.Device) interface{} { return d.Name }),
+		newColumn("Description", func(d *dtos.Device) interface{} { return d.Description }),
+		newColumn("ServiceName", func(d *dtos.Device) interface{} { return d.ServiceName }),
+		newColumn("ProfileName", func(d *dtos.Device) interface{} { return d.ProfileName }),
+		newColumn("AdminState", func(d *dtos.Device) interface{} { return d.AdminState }),
+		newColumn("OperatingState", func(d *dtos.Device) interface{} { return d.OperatingState }),
+		newColumn("LastReported", func(d *dtos.Device) interface{} { return d.LastReported }).withFormat(formatMillis),
+		newColumn("LastConnected", func(d *dtos.Device) interface{} { return d.LastConnected }).withFormat(formatMillis),
+		newColumn("Labels", func(d *dtos.Device) interface{} { return d.Labels }),
+		newColumn("Location", func(d *dtos.Device) interface{} { return d.Location }),
+		newColumn("AutoEvents", func(d *dtos.Device) interface{} { return d.AutoEvents }),
+		newColumn("Protocols", func(d *dtos.Device) interface{} { return d.Protocols }),
+	},
+	defaults: []string{"Name", "Description", "ServiceName", "ProfileName", "Labels", "AutoEvents"},
 }
@@ -21,8 +21,6 @@ import (
 	jsonpkg "encoding/json"
 	"errors"
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
@@ -105,6 +103,7 @@ func initListDeviceProfileCommand(cmd *cobra.Command) {
 
 	cmd.AddCommand(listCmd)
 	addFormatFlags(listCmd)
+	addTableFlags(listCmd)
 	addVerboseFlag(listCmd)
 	addLimitOffsetFlags(listCmd)
 	addLabelsFlag(listCmd)
@@ -123,6 +122,7 @@ func initGetDeviceProfileByNameCommand(cmd *cobra.Command) {
 	nameCmd.Flags().StringVarP(&deviceProfileName, "name", "n", "", "Device profile name")
 	nameCmd.MarkFlagRequired("name")
 	addFormatFlags(nameCmd)
+	addTableFlags(nameCmd)
 	addVerboseFlag(nameCmd)
 	cmd.AddCommand(nameCmd)
 
@@ -153,10 +153,7 @@ func handleGetDeviceProfileByName(cmd *cobra.Command, args []string) error {
 
 		fmt.Println(string(result))
 	} else {
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printProfileTableHeader(w)
-		printProfile(w, &response.Profile)
-		w.Flush()
+		return printRows(profileTable, []dtos.DeviceProfile{response.Profile})
 	}
 	return nil
 
@@ -229,45 +226,22 @@ func handleListDeviceProfile(cmd *cobra.Command, args []string) error {
 			fmt.Println("No profiles available")
 			return nil
 		}
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printProfileTableHeader(w)
-		for _, p := range response.Profiles {
-			printProfile(w, &p)
-		}
-		w.Flush()
+		return printRows(profileTable, response.Profiles)
 	}
 	return nil
 }
 
-func printProfileTableHeader(w *tabwriter.Writer) {
-	if verbose {
-		fmt.Fprintln(w, "Id\tName\tCreated\tDescription\t# DeviceCommands\t# DeviceResources\tManufacturer\tModel\tName")
-
-	} else {
-		fmt.Fprintln(w, "Name\tDescription\tManufacturer\tModel\tName")
-	}
-
-}
-
-func printProfile(w *tabwriter.Writer, p *dtos.DeviceProfile) {
-	if verbose {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			p.Id,
-			p.Name,
-			getRFC822Time(p.Created),
-			p.Description,
-			len(p.DeviceCommands),
-			len(p.DeviceResources),
-			p.Manufacturer,
-			p.Model,
-			p.Name)
-	} else {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n",
-			p.Name,
-			p.Description,
-			p.Manufacturer,
-			p.Model,
-			p.Name)
-	}
-
+// profileTable defines the columns of tables of device profiles
+var profileTable = table{
+	columns: []column{
+		newColumn("Id", func(p *dtos.DeviceProfile) interface{} { return p.Id }),
+		newColumn("Name", func(p *dtos.DeviceProfile) interface{} { return p.Name }),
+		newColumn("Created", func(p *dtos.DeviceProfile) interface{} { return p.Created }).withFormat(formatMillis),
+		newColumn("Description", func(p *dtos.DeviceProfile) interface{} { return p.Description }),
+		newColumn("# DeviceCommands", func(p *dtos.DeviceProfile) interface{} { return len(p.DeviceCommands) }),
+		newColumn("# DeviceResources", func(p *dtos.DeviceProfile) interface{} { return len(p.DeviceResources) }),
+		newColumn("Manufacturer", func(p *dtos.DeviceProfile) interface{} { return p.Manufacturer }),
+		newColumn("Model", func(p *dtos.DeviceProfile) interface{} { return p.Model }),
+	},
+	defaults: []string{"Name", "Description", "Manufacturer", "Model"},
 }
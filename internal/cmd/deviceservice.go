@@ -20,8 +20,6 @@ import (
 	"context"
 	jsonpkg "encoding/json"
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
@@ -101,6 +99,7 @@ func initListDeviceServiceCommand(cmd *cobra.Command) {
 
 	cmd.AddCommand(listCmd)
 	addFormatFlags(listCmd)
+	addTableFlags(listCmd)
 	addVerboseFlag(listCmd)
 	addLimitOffsetFlags(listCmd)
 	addLabelsFlag(listCmd)
@@ -148,6 +147,7 @@ func initGetDeviceServiceByNameCommand(cmd *cobra.Command) {
 	nameCmd.Flags().StringVarP(&deviceServiceName, "name", "n", "", "Device name")
 	nameCmd.MarkFlagRequired("device")
 	addFormatFlags(nameCmd)
+	addTableFlags(nameCmd)
 	addVerboseFlag(nameCmd)
 	cmd.AddCommand(nameCmd)
 
@@ -203,10 +203,7 @@ func handleGetDeviceServiceByName(cmd *cobra.Command, args []string) error {
 
 		fmt.Println(string(result))
 	} else {
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printServiceTableHeader(w)
-		printService(w, &response.Service)
-		w.Flush()
+		return printRows(serviceTable, []dtos.DeviceService{response.Service})
 	}
 	return nil
 
@@ -232,12 +229,7 @@ func handleListDeviceServices(cmd *cobra.Command, args []string) error {
 			fmt.Println("No device services available")
 			return nil
 		}
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printServiceTableHeader(w)
-		for _, p := range response.Services {
-			printService(w, &p)
-		}
-		w.Flush()
+		return printRows(serviceTable, response.Services)
 	}
 	return nil
 }
@@ -283,32 +275,18 @@ func handleUpdateDeviceService(cmd *cobra.Command, args []string) (err error) {
 	return err
 }
 
-func printServiceTableHeader(w *tabwriter.Writer) {
-	if verbose {
-		fmt.Fprintln(w, "Name\tBaseAddress\tDescription\tAdminState\tId\tLabels\tLastConnected\tLastReported\tModified")
-	} else {
-		fmt.Fprintln(w, "Name\tBaseAddress\tDescription")
-	}
-
-}
-
-func printService(w *tabwriter.Writer, deviceService *dtos.DeviceService) {
-	if verbose {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%s\t%s\t%s\t%s\n",
-			deviceService.Name,
-			deviceService.BaseAddress,
-			deviceService.Description,
-			deviceService.AdminState,
-			deviceService.Id,
-			deviceService.Labels,
-			getRFC822Time(deviceService.LastConnected),
-			getRFC822Time(deviceService.LastReported),
-			getRFC822Time(deviceService.Modified))
-	} else {
-		fmt.Fprintf(w, "%s\t%s\t%s\n",
-			deviceService.Name,
-			deviceService.BaseAddress,
-			deviceService.Description)
-	}
-
+// serviceTable defines the columns of tables of device services
+var serviceTable = table{
+	columns: []column{
+		newColumn("Name", func(s *dtos.DeviceService) interface{} { return s.Name }),
+		newColumn("BaseAddress", func(s *dtos.DeviceService) interface{} { return s.BaseAddress }),
+		newColumn("Description", func(s *dtos.DeviceService) interface{} { return s.Description }),
+		newColumn("AdminState", func(s *dtos.DeviceService) interface{} { return s.AdminState }),
+		newColumn("Id", func(s *dtos.DeviceService) interface{} { return s.Id }),
+		newColumn("Labels", func(s *dtos.DeviceService) interface{} { return s.Labels }),
+		newColumn("LastConnected", func(s *dtos.DeviceService) interface{} { return s.LastConnected }).withFormat(formatMillis),
+		newColumn("LastReported", func(s *dtos.DeviceService) interface{} { return s.LastReported }).withFormat(formatMillis),
+		newColumn("Modified", func(s *dtos.DeviceService) interface{} { return s.Modified }).withFormat(formatMillis),
+	},
+	defaults: []string{"Name", "BaseAddress", "Description"},
 }
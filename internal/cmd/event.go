@@ -22,22 +22,23 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
-	"os"
 	"strconv"
 	"strings"
-	"text/tabwriter"
 	"time"
 
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/interfaces"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	dtosCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/responses"
 	"github.com/spf13/cobra"
 )
 
 var eventLimit, eventOffset int
 var eventDevice, eventProfile, eventSource, readingsValueType string
-var eventAge int
+var eventStart, eventEnd string
+var eventAge time.Duration
 var numberOfReadings int
 
 func init() {
@@ -46,6 +47,7 @@ func init() {
 	initCountEventCommand(eventCmd)
 	initRmEventCommand(eventCmd)
 	initAddEventCommand(eventCmd)
+	initGenerateEventCommand(eventCmd)
 }
 
 func initEventCommand() *cobra.Command {
@@ -62,30 +64,42 @@ func initEventCommand() *cobra.Command {
 
 func initListEventCommand(cmd *cobra.Command) {
 	var listCmd = &cobra.Command{
-		Use:          "list",
-		Short:        "List events",
-		Long:         `List all events, optionally specifying a limit and offset`,
+		Use:   "list",
+		Short: "List events",
+		Long:  `List all events, optionally filtered by device name or time range and specifying a limit and offset`,
+		Example: `  edgex-cli event list --device "Random-Integer-Device"
+  edgex-cli event list --start -15m
+  edgex-cli event list --start "2022-11-01T00:00:00Z" --end "2022-11-02T00:00:00Z"`,
 		RunE:         handleListEvents,
 		SilenceUsage: true,
 	}
 
 	cmd.AddCommand(listCmd)
 	addFormatFlags(listCmd)
+	addTableFlags(listCmd)
 	addVerboseFlag(listCmd)
 	listCmd.Flags().IntVarP(&eventLimit, "limit", "l", 50, "The number of items to return. Specifying -1 will return all remaining items")
 	listCmd.Flags().IntVarP(&eventOffset, "offset", "o", 0, "The number of items to skip")
+	listCmd.Flags().StringVarP(&eventDevice, "device", "d", "", "List events from this device, which may be combined with --start and --end")
+	addEventTimeRangeFlags(listCmd)
+}
+
+func addEventTimeRangeFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&eventStart, "start", "", "", "Only include events created after this time ("+timeArgHelp+")")
+	cmd.Flags().StringVarP(&eventEnd, "end", "", "", "Only include events created before this time ("+timeArgHelp+")")
 }
 
 func initCountEventCommand(cmd *cobra.Command) {
 	var countCmd = &cobra.Command{
 		Use:          "count",
 		Short:        "Count available events",
-		Long:         `Count the number of events in core data, optionally filtering by device name`,
+		Long:         `Count the number of events in core data, optionally filtering by device name or time range`,
 		RunE:         handleCountEvents,
 		SilenceUsage: true,
 	}
 
 	countCmd.Flags().StringVarP(&eventDevice, "device", "d", "", "Device name")
+	addEventTimeRangeFlags(countCmd)
 	cmd.AddCommand(countCmd)
 	addFormatFlags(countCmd)
 }
@@ -94,16 +108,16 @@ func initRmEventCommand(cmd *cobra.Command) {
 	var rmCmd = &cobra.Command{
 		Use:   "rm",
 		Short: "Remove events",
-		Long: `Remove events, specifying either device name or maximum event age in milliseconds
+		Long: `Remove events, specifying either device name or maximum event age
  
 'edgex-cli event rm --device {devicename}' removes all events for the specified device
-'edgex-cli event rm --age {ms}' removes all events generated in the last {ms} milliseconds`,
+'edgex-cli event rm --age {age}' removes all events older than {age}, e.g. 7d, 12h or a number of milliseconds`,
 		RunE:         handleRmEvents,
 		SilenceUsage: true,
 	}
 
 	rmCmd.Flags().StringVarP(&eventDevice, "device", "d", "", "Device name")
-	rmCmd.Flags().IntVarP(&eventAge, "age", "a", 0, "Event age (in milliseconds)")
+	rmCmd.Flags().VarP(newDurationValue(0, &eventAge), "age", "a", "Event age (e.g. 7d, 12h, or in milliseconds)")
 	cmd.AddCommand(rmCmd)
 }
 
@@ -191,7 +205,7 @@ func handleRmEvents(cmd *cobra.Command, args []string) error {
 	} else if eventDevice != "" {
 		client.DeleteByDeviceName(context.Background(), eventDevice)
 	} else if eventAge != 0 {
-		client.DeleteByAge(context.Background(), eventAge)
+		client.DeleteByAge(context.Background(), int(eventAge.Milliseconds()))
 	} else {
 		return errors.New("event ID, device name or event age must be specified")
 	}
@@ -205,7 +219,12 @@ func handleCountEvents(cmd *cobra.Command, args []string) error {
 	var response dtosCommon.CountResponse
 	var err error
 
-	if eventDevice != "" {
+	if eventStart != "" || eventEnd != "" {
+		// there is no count endpoint for a time range, so use the total count of a query instead
+		var events responses.MultiEventsResponse
+		events, err = queryEvents(client, 0, 1)
+		response = dtosCommon.NewCountResponse(events.RequestId, events.Message, events.StatusCode, events.TotalCount)
+	} else if eventDevice != "" {
 		response, err = client.EventCountByDeviceName(context.Background(), eventDevice)
 	} else {
 		response, err = client.EventCount(context.Background())
@@ -236,7 +255,7 @@ func handleListEvents(cmd *cobra.Command, args []string) error {
 	var err error
 
 	client := getCoreDataService().GetEventClient()
-	response, err := client.AllEvents(context.Background(), eventOffset, eventLimit)
+	response, err := queryEvents(client, eventOffset, eventLimit)
 	if err != nil {
 		return err
 	}
@@ -255,34 +274,74 @@ func handleListEvents(cmd *cobra.Command, args []string) error {
 			return nil
 		}
 
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		if verbose {
-			fmt.Fprintln(w, "Origin\tDevice\tProfile\tSource\tId\tVersionable\tReadings")
-			for _, event := range response.Events {
-				fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-					time.Unix(0, event.Origin).Format(time.RFC822),
-					event.DeviceName,
-					event.ProfileName,
-					event.SourceName,
-					event.Id,
-					event.Versionable,
-					event.Readings)
-			}
+		return printRows(eventTable, response.Events)
+	}
+	return nil
+}
 
-		} else {
-			fmt.Fprintln(w, "Origin\tDevice\tProfile\tSource\tNumber of readings")
-			for _, event := range response.Events {
-				tm := time.Unix(0, event.Origin)
-				sTime := tm.Format(time.RFC822)
-				nReadings := 0
-				if event.Readings != nil {
-					nReadings = len(event.Readings)
-				}
-				fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%d\n",
-					sTime, event.DeviceName, event.ProfileName, event.SourceName, nReadings)
-			}
+// queryEvents selects the core-data query matching the device and time range flags
+func queryEvents(client interfaces.EventClient, offset int, limit int) (responses.MultiEventsResponse, error) {
+	if eventStart != "" || eventEnd != "" {
+		start, end, err := getNanosTimeRange(eventStart, eventEnd)
+		if err != nil {
+			return responses.MultiEventsResponse{}, err
+		}
+		if eventDevice != "" {
+			return queryDeviceEventsByTimeRange(client, start, end, offset, limit)
 		}
-		w.Flush()
+		return client.EventsByTimeRange(context.Background(), start, end, offset, limit)
+	} else if eventDevice != "" {
+		return client.EventsByDeviceName(context.Background(), eventDevice, offset, limit)
 	}
-	return nil
+	return client.AllEvents(context.Background(), offset, limit)
+}
+
+// queryDeviceEventsByTimeRange returns the events of the device flag within a time range. core-data
+// can't filter by both, so the events of the time range are fetched and filtered by device, and the
+// offset and limit are applied to the filtered events.
+func queryDeviceEventsByTimeRange(client interfaces.EventClient, start int, end int, offset int, limit int) (responses.MultiEventsResponse, error) {
+	var response responses.MultiEventsResponse
+	events, err := fetchAll(func(offset int, limit int) ([]dtos.Event, uint32, error) {
+		r, err := client.EventsByTimeRange(context.Background(), start, end, offset, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		response = r
+		return r.Events, r.TotalCount, nil
+	})
+	if err != nil {
+		return responses.MultiEventsResponse{}, err
+	}
+
+	var matching []dtos.Event
+	for _, e := range events {
+		if e.DeviceName == eventDevice {
+			matching = append(matching, e)
+		}
+	}
+	total := len(matching)
+	if offset > len(matching) {
+		offset = len(matching)
+	}
+	matching = matching[offset:]
+	if limit >= 0 && limit < len(matching) {
+		matching = matching[:limit]
+	}
+	return responses.NewMultiEventsResponse(response.RequestId, response.Message, response.StatusCode, uint32(total), matching), nil
+}
+
+// eventTable defines the columns of tables of events
+var eventTable = table{
+	columns: []column{
+		newColumn("Origin", func(e *dtos.Event) interface{} { return e.Origin }).withFormat(formatNanos),
+		newColumn("Device", func(e *dtos.Event) interface{} { return e.DeviceName }),
+		newColumn("Profile", func(e *dtos.Event) interface{} { return e.ProfileName }),
+		newColumn("Source", func(e *dtos.Event) interface{} { return e.SourceName }),
+		newColumn("Id", func(e *dtos.Event) interface{} { return e.Id }),
+		newColumn("Versionable", func(e *dtos.Event) interface{} { return e.Versionable }),
+		newColumn("Readings", func(e *dtos.Event) interface{} { return e.Readings }),
+		newColumn("Number of readings", func(e *dtos.Event) interface{} { return len(e.Readings) }),
+	},
+	defaults: []string{"Origin", "Device", "Profile", "Source", "Number of readings"},
+	verbose:  []string{"Origin", "Device", "Profile", "Source", "Id", "Versionable", "Readings"},
 }
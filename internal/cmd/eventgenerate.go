@@ -0,0 +1,494 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
+	"github.com/spf13/cobra"
+)
+
+var eventProfileFile, eventWaveform string
+var eventRate float64
+var eventDuration, eventPeriod time.Duration
+var eventConcurrency, eventBinarySize int
+
+func initGenerateEventCommand(cmd *cobra.Command) {
+	var generateCmd = &cobra.Command{
+		Use:   "generate",
+		Short: "Generate events for load and pipeline testing",
+		Long: `Generate events at a target rate, with readings that match the resources of a device profile.
+
+The profile is read from core-metadata or from a JSON file. Readings respect each resource's
+value type, minimum and maximum, units and media type. If no source is specified, the
+readable resources of the profile are used in turn, one per event. If the source is a
+device command, each event contains a reading for every resource of the command.
+
+Supported waveforms are random, sine, ramp and walk (random walk). Sine and ramp repeat
+every --period.`,
+		Example: `  edgex-cli event generate --device "Random-Integer-Device" --profile "Random-Integer-Device" --rate 10 --duration 1m
+  edgex-cli event generate --device "dev01" --profile-file ./profile.json --source "Temperature" --waveform sine --period 30s
+  edgex-cli event generate --device "dev01" --profile "profile01" --rate 0 --concurrency 8 --duration 30s`,
+		RunE:         handleGenerateEvents,
+		SilenceUsage: true,
+	}
+	generateCmd.Flags().StringVarP(&eventDevice, "device", "d", "", "Device name")
+	generateCmd.Flags().StringVarP(&eventProfile, "profile", "p", "", "Name of the device profile in core-metadata")
+	generateCmd.Flags().StringVarP(&eventProfileFile, "profile-file", "", "", "JSON file containing the device profile")
+	generateCmd.Flags().StringVarP(&eventSource, "source", "s", "", "Event source name (ResourceName or CommandName)")
+	generateCmd.Flags().StringVarP(&eventWaveform, "waveform", "w", "random", "Waveform of the reading values [random | sine | ramp | walk]")
+	generateCmd.Flags().DurationVarP(&eventPeriod, "period", "", time.Minute, "Period of the sine and ramp waveforms")
+	generateCmd.Flags().Float64VarP(&eventRate, "rate", "r", 1, "Target number of events per second, 0 sends as fast as possible")
+	generateCmd.Flags().DurationVarP(&eventDuration, "duration", "", 10*time.Second, "How long to generate events for")
+	generateCmd.Flags().IntVarP(&eventConcurrency, "concurrency", "c", 1, "Number of events sent in parallel")
+	generateCmd.Flags().IntVarP(&eventBinarySize, "binary-size", "", 64, "Size in bytes of generated binary readings")
+	generateCmd.MarkFlagRequired("device")
+	cmd.AddCommand(generateCmd)
+}
+
+// readingGenerator generates reading values for a device resource
+type readingGenerator struct {
+	resource dtos.DeviceResource
+	min, max float64
+	mu       sync.Mutex
+	walk     float64
+}
+
+// eventSourceGenerator generates the events of a single source
+type eventSourceGenerator struct {
+	name       string
+	generators []*readingGenerator
+}
+
+func handleGenerateEvents(cmd *cobra.Command, args []string) error {
+	if (eventProfile == "") == (eventProfileFile == "") {
+		return errors.New("please specify the device profile using one of --profile or --profile-file")
+	}
+	if eventConcurrency < 1 {
+		return errors.New("the concurrency must be at least 1")
+	}
+	if eventRate < 0 {
+		return errors.New("the rate must not be negative")
+	}
+	// the ticker interval must be at least a nanosecond
+	if eventRate > float64(time.Second) {
+		return fmt.Errorf("the rate must not exceed %d events per second", time.Second)
+	}
+	if eventPeriod <= 0 {
+		return errors.New("the period must be a positive duration")
+	}
+	switch eventWaveform {
+	case "random", "sine", "ramp", "walk":
+	default:
+		return errors.New("waveform must be one of [random | sine | ramp | walk]")
+	}
+
+	profile, err := getGenerateEventProfile()
+	if err != nil {
+		return err
+	}
+	sources, err := getEventSourceGenerators(profile)
+	if err != nil {
+		return err
+	}
+
+	client := getCoreDataService().GetEventClient()
+	jobs := make(chan int64)
+	var sent, readings, failed int64
+	var errorsMu sync.Mutex
+	errorCounts := map[string]int{}
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < eventConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range jobs {
+				source := sources[int(n)%len(sources)]
+				event, err := source.generate(profile.Name, eventDevice, time.Since(start))
+				if err == nil {
+					_, err = client.Add(context.Background(), requests.NewAddEventRequest(event))
+				}
+				if err != nil {
+					atomic.AddInt64(&failed, 1)
+					errorsMu.Lock()
+					errorCounts[err.Error()]++
+					errorsMu.Unlock()
+					continue
+				}
+				atomic.AddInt64(&sent, 1)
+				atomic.AddInt64(&readings, int64(len(event.Readings)))
+			}
+		}()
+	}
+
+	deadline := time.After(eventDuration)
+	var tick <-chan time.Time
+	if eventRate > 0 {
+		ticker := time.NewTicker(time.Duration(float64(time.Second) / eventRate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+generate:
+	for n := int64(0); ; n++ {
+		if tick != nil {
+			select {
+			case <-tick:
+			case <-deadline:
+				break generate
+			}
+		}
+		select {
+		case jobs <- n:
+		case <-deadline:
+			break generate
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	fmt.Printf("Sent %d events with %d readings in %v (%.1f events/s, %.1f readings/s)\n",
+		sent, readings, elapsed.Round(time.Millisecond),
+		float64(sent)/elapsed.Seconds(), float64(readings)/elapsed.Seconds())
+	if failed > 0 {
+		fmt.Printf("%d events failed:\n", failed)
+		for msg, count := range errorCounts {
+			fmt.Printf("  %d x %s\n", count, msg)
+		}
+		return fmt.Errorf("%d of %d events failed", failed, sent+failed)
+	}
+	return nil
+}
+
+// getGenerateEventProfile returns the device profile from core-metadata or a JSON file
+func getGenerateEventProfile() (dtos.DeviceProfile, error) {
+	if eventProfile != "" {
+		response, err := getCoreMetaDataService().GetDeviceProfileClient().DeviceProfileByName(context.Background(), eventProfile)
+		if err != nil {
+			return dtos.DeviceProfile{}, err
+		}
+		return response.Profile, nil
+	}
+
+	content, err := os.ReadFile(eventProfileFile)
+	if err != nil {
+		return dtos.DeviceProfile{}, err
+	}
+	// accept both a plain profile and the response of the deviceprofile name command
+	var wrapped struct {
+		Profile *dtos.DeviceProfile `json:"profile"`
+	}
+	if err := jsonpkg.Unmarshal(content, &wrapped); err == nil && wrapped.Profile != nil {
+		return *wrapped.Profile, nil
+	}
+	var profile dtos.DeviceProfile
+	if err := jsonpkg.Unmarshal(content, &profile); err != nil {
+		return dtos.DeviceProfile{}, fmt.Errorf("invalid device profile JSON in %s (%v)", eventProfileFile, err)
+	}
+	if profile.Name == "" {
+		return dtos.DeviceProfile{}, fmt.Errorf("the device profile in %s has no name", eventProfileFile)
+	}
+	return profile, nil
+}
+
+// getEventSourceGenerators returns the generators for the --source flag, or for every
+// readable resource of the profile if no source is specified
+func getEventSourceGenerators(profile dtos.DeviceProfile) ([]*eventSourceGenerator, error) {
+	resources := make(map[string]dtos.DeviceResource, len(profile.DeviceResources))
+	for _, r := range profile.DeviceResources {
+		resources[r.Name] = r
+	}
+
+	if eventSource == "" {
+		var sources []*eventSourceGenerator
+		for _, r := range profile.DeviceResources {
+			if r.IsHidden || !strings.Contains(r.Properties.ReadWrite, "R") {
+				continue
+			}
+			g, err := newReadingGenerator(r)
+			if err != nil {
+				return nil, err
+			}
+			sources = append(sources, &eventSourceGenerator{name: r.Name, generators: []*readingGenerator{g}})
+		}
+		if len(sources) == 0 {
+			return nil, fmt.Errorf("device profile %s has no readable resources", profile.Name)
+		}
+		return sources, nil
+	}
+
+	source := &eventSourceGenerator{name: eventSource}
+	if r, ok := resources[eventSource]; ok {
+		g, err := newReadingGenerator(r)
+		if err != nil {
+			return nil, err
+		}
+		source.generators = append(source.generators, g)
+		return []*eventSourceGenerator{source}, nil
+	}
+	for _, c := range profile.DeviceCommands {
+		if c.Name != eventSource {
+			continue
+		}
+		for _, op := range c.ResourceOperations {
+			r, ok := resources[op.DeviceResource]
+			if !ok {
+				return nil, fmt.Errorf("device command %s refers to unknown resource %s", c.Name, op.DeviceResource)
+			}
+			g, err := newReadingGenerator(r)
+			if err != nil {
+				return nil, err
+			}
+			source.generators = append(source.generators, g)
+		}
+		return []*eventSourceGenerator{source}, nil
+	}
+	return nil, fmt.Errorf("device profile %s has no resource or command named %s", profile.Name, eventSource)
+}
+
+func (s *eventSourceGenerator) generate(profileName string, deviceName string, elapsed time.Duration) (dtos.Event, error) {
+	event := dtos.NewEvent(profileName, deviceName, s.name)
+	for _, g := range s.generators {
+		reading, err := g.generate(profileName, deviceName, elapsed)
+		if err != nil {
+			return dtos.Event{}, err
+		}
+		event.Readings = append(event.Readings, reading)
+	}
+	return event, nil
+}
+
+// getValueTypeRange returns the range of values generated for a value type when the
+// resource does not specify a minimum or maximum
+func getValueTypeRange(valueType string) (float64, float64) {
+	switch strings.TrimSuffix(valueType, "Array") {
+	case common.ValueTypeUint8:
+		return 0, math.MaxUint8
+	case common.ValueTypeUint16:
+		return 0, math.MaxUint16
+	case common.ValueTypeUint32:
+		return 0, math.MaxUint32
+	case common.ValueTypeUint64:
+		return 0, math.MaxUint32 * 1024
+	case common.ValueTypeInt8:
+		return math.MinInt8, math.MaxInt8
+	case common.ValueTypeInt16:
+		return math.MinInt16, math.MaxInt16
+	case common.ValueTypeInt32:
+		return math.MinInt32, math.MaxInt32
+	case common.ValueTypeInt64:
+		return math.MinInt32 * 1024, math.MaxInt32 * 1024
+	}
+	return 0, 100
+}
+
+func newReadingGenerator(r dtos.DeviceResource) (*readingGenerator, error) {
+	g := &readingGenerator{resource: r, walk: 0.5}
+	g.min, g.max = getValueTypeRange(r.Properties.ValueType)
+	if r.Properties.Minimum != "" {
+		min, err := strconv.ParseFloat(r.Properties.Minimum, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid minimum %s of resource %s", r.Properties.Minimum, r.Name)
+		}
+		g.min = min
+	}
+	if r.Properties.Maximum != "" {
+		max, err := strconv.ParseFloat(r.Properties.Maximum, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid maximum %s of resource %s", r.Properties.Maximum, r.Name)
+		}
+		g.max = max
+	}
+	if g.max < g.min {
+		return nil, fmt.Errorf("maximum of resource %s is less than its minimum", r.Name)
+	}
+	return g, nil
+}
+
+// level returns the waveform level between 0 and 1 at the elapsed time
+func (g *readingGenerator) level(elapsed time.Duration) float64 {
+	phase := float64(elapsed%eventPeriod) / float64(eventPeriod)
+	switch eventWaveform {
+	case "sine":
+		return 0.5 + 0.5*math.Sin(2*math.Pi*phase)
+	case "ramp":
+		return phase
+	case "walk":
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		g.walk = math.Max(0, math.Min(1, g.walk+rand.NormFloat64()*0.05))
+		return g.walk
+	}
+	return rand.Float64()
+}
+
+// value returns a value of the given scalar value type at the elapsed time
+func (g *readingGenerator) value(valueType string, elapsed time.Duration) interface{} {
+	v := g.min + g.level(elapsed)*(g.max-g.min)
+	switch valueType {
+	case common.ValueTypeBool:
+		return v >= (g.min+g.max)/2
+	case common.ValueTypeString:
+		return strconv.FormatFloat(v, 'f', 2, 64)
+	case common.ValueTypeUint8:
+		return uint8(math.Round(v))
+	case common.ValueTypeUint16:
+		return uint16(math.Round(v))
+	case common.ValueTypeUint32:
+		return uint32(math.Round(v))
+	case common.ValueTypeUint64:
+		return uint64(math.Round(v))
+	case common.ValueTypeInt8:
+		return int8(math.Round(v))
+	case common.ValueTypeInt16:
+		return int16(math.Round(v))
+	case common.ValueTypeInt32:
+		return int32(math.Round(v))
+	case common.ValueTypeInt64:
+		return int64(math.Round(v))
+	case common.ValueTypeFloat32:
+		return float32(v)
+	}
+	return v
+}
+
+func (g *readingGenerator) generate(profileName string, deviceName string, elapsed time.Duration) (dtos.BaseReading, error) {
+	props := g.resource.Properties
+	var reading dtos.BaseReading
+	switch props.ValueType {
+	case common.ValueTypeBinary:
+		value := make([]byte, eventBinarySize)
+		rand.Read(value)
+		mediaType := props.MediaType
+		if mediaType == "" {
+			mediaType = "application/octet-stream"
+		}
+		reading = dtos.NewBinaryReading(profileName, deviceName, g.resource.Name, value, mediaType)
+	case common.ValueTypeObject:
+		reading = dtos.NewObjectReading(profileName, deviceName, g.resource.Name, map[string]interface{}{
+			"value": g.value(common.ValueTypeFloat64, elapsed),
+		})
+	default:
+		var value interface{}
+		if strings.HasSuffix(props.ValueType, "Array") {
+			value = g.arrayValue(strings.TrimSuffix(props.ValueType, "Array"), elapsed, 3)
+		} else {
+			value = g.value(props.ValueType, elapsed)
+		}
+		var err error
+		reading, err = dtos.NewSimpleReading(profileName, deviceName, g.resource.Name, props.ValueType, value)
+		if err != nil {
+			return dtos.BaseReading{}, fmt.Errorf("resource %s: %v", g.resource.Name, err)
+		}
+	}
+	reading.Units = props.Units
+	return reading, nil
+}
+
+// arrayValue returns a typed slice of n values of the given element type
+func (g *readingGenerator) arrayValue(elementType string, elapsed time.Duration, n int) interface{} {
+	switch elementType {
+	case common.ValueTypeBool:
+		a := make([]bool, n)
+		for i := range a {
+			a[i] = g.value(elementType, elapsed).(bool)
+		}
+		return a
+	case common.ValueTypeString:
+		a := make([]string, n)
+		for i := range a {
+			a[i] = g.value(elementType, elapsed).(string)
+		}
+		return a
+	case common.ValueTypeUint8:
+		a := make([]uint8, n)
+		for i := range a {
+			a[i] = g.value(elementType, elapsed).(uint8)
+		}
+		return a
+	case common.ValueTypeUint16:
+		a := make([]uint16, n)
+		for i := range a {
+			a[i] = g.value(elementType, elapsed).(uint16)
+		}
+		return a
+	case common.ValueTypeUint32:
+		a := make([]uint32, n)
+		for i := range a {
+			a[i] = g.value(elementType, elapsed).(uint32)
+		}
+		return a
+	case common.ValueTypeUint64:
+		a := make([]uint64, n)
+		for i := range a {
+			a[i] = g.value(elementType, elapsed).(uint64)
+		}
+		return a
+	case common.ValueTypeInt8:
+		a := make([]int8, n)
+		for i := range a {
+			a[i] = g.value(elementType, elapsed).(int8)
+		}
+		return a
+	case common.ValueTypeInt16:
+		a := make([]int16, n)
+		for i := range a {
+			a[i] = g.value(elementType, elapsed).(int16)
+		}
+		return a
+	case common.ValueTypeInt32:
+		a := make([]int32, n)
+		for i := range a {
+			a[i] = g.value(elementType, elapsed).(int32)
+		}
+		return a
+	case common.ValueTypeInt64:
+		a := make([]int64, n)
+		for i := range a {
+			a[i] = g.value(elementType, elapsed).(int64)
+		}
+		return a
+	case common.ValueTypeFloat32:
+		a := make([]float32, n)
+		for i := range a {
+			a[i] = g.value(elementType, elapsed).(float32)
+		}
+		return a
+	}
+	a := make([]float64, n)
+	for i := range a {
+		a[i] = g.value(elementType, elapsed).(float64)
+	}
+	return a
+}
@@ -21,8 +21,6 @@ import (
 	jsonpkg "encoding/json"
 	"errors"
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
@@ -44,6 +42,7 @@ func init() {
 	initAddIntervalCommand(cmd)
 	initRmIntervalCommand(cmd)
 	initUpdateIntervalCommand(cmd)
+	initNextIntervalCommand(cmd)
 }
 
 // initListIntervalCommand implements support for the GET /interval/all endpoint
@@ -58,6 +57,7 @@ func initListIntervalCommand(cmd *cobra.Command) {
 		SilenceUsage: true,
 	}
 	addFormatFlags(listCmd)
+	addTableFlags(listCmd)
 	addVerboseFlag(listCmd)
 	addLimitOffsetFlags(listCmd)
 	cmd.AddCommand(listCmd)
@@ -76,6 +76,7 @@ func initGetIntervalByNameCommand(cmd *cobra.Command) {
 	nameCmd.Flags().StringVarP(&intervalName, "name", "n", "", "Interval name")
 	nameCmd.MarkFlagRequired("name")
 	addFormatFlags(nameCmd)
+	addTableFlags(nameCmd)
 	addVerboseFlag(nameCmd)
 	cmd.AddCommand(nameCmd)
 
@@ -94,8 +95,8 @@ func initAddIntervalCommand(cmd *cobra.Command) {
 	}
 	add.Flags().StringVarP(&intervalName, "name", "n", "", "Non-database identifier for an interval (*must be unique)")
 	add.Flags().StringVarP(&intervalInterval, "interval", "i", "", "Interval indicates how often the specific resource needs to be polled (e.g. 100ms, 24h)")
-	add.Flags().StringVarP(&intervalStart, "start", "s", "", "Start time in ISO 8601 format YYYYMMDD'T'HHmmss")
-	add.Flags().StringVarP(&intervalEnd, "end", "e", "", "End time in ISO 8601 format YYYYMMDD'T'HHmmss")
+	add.Flags().StringVarP(&intervalStart, "start", "s", "", "Start time ("+timeArgHelp+")")
+	add.Flags().StringVarP(&intervalEnd, "end", "e", "", "End time ("+timeArgHelp+")")
 
 	add.MarkFlagRequired("name")
 	add.MarkFlagRequired("interval")
@@ -130,8 +131,8 @@ func initUpdateIntervalCommand(cmd *cobra.Command) {
 	add.Flags().StringVarP(&intervalId, "id", "", "", "Uniquely identifies the interval, either id or name should be specified.")
 	add.Flags().StringVarP(&intervalName, "name", "n", "", "Non-database identifier for an interval (*must be unique), either id or name should be specified")
 	add.Flags().StringVarP(&intervalInterval, "interval", "i", "", "Interval indicates how often the specific resource needs to be polled (e.g. 100ms, 24h)")
-	add.Flags().StringVarP(&intervalStart, "start", "s", "", "Start time in ISO 8601 format YYYYMMDD'T'HHmmss")
-	add.Flags().StringVarP(&intervalEnd, "end", "e", "", "End time in ISO 8601 format YYYYMMDD'T'HHmmss")
+	add.Flags().StringVarP(&intervalStart, "start", "s", "", "Start time ("+timeArgHelp+")")
+	add.Flags().StringVarP(&intervalEnd, "end", "e", "", "End time ("+timeArgHelp+")")
 
 	cmd.AddCommand(add)
 }
@@ -149,6 +150,9 @@ func handleUpdateInterval(cmd *cobra.Command, args []string) error {
 	if name == nil && id == nil {
 		return errors.New("either id or name should be specified")
 	}
+	if err := resolveIntervalTimes(); err != nil {
+		return err
+	}
 	if intervalStart != "" {
 		start = &intervalStart
 	}
@@ -181,6 +185,9 @@ func handleRmInterval(cmd *cobra.Command, args []string) error {
 }
 
 func handleAddInterval(cmd *cobra.Command, args []string) error {
+	if err := resolveIntervalTimes(); err != nil {
+		return err
+	}
 	client := getSupportSchedulerService().GetIntervalClient()
 	var req = requests.NewAddIntervalRequest(dtos.Interval{
 		Name:     intervalName,
@@ -211,10 +218,7 @@ func handleGetIntervalByName(cmd *cobra.Command, args []string) error {
 
 		fmt.Println(string(result))
 	} else {
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printIntervalTableHeader(w)
-		printInterval(w, &response.Interval)
-		w.Flush()
+		return printRows(intervalTable, []dtos.Interval{response.Interval})
 	}
 	return nil
 }
@@ -238,38 +242,29 @@ func handleListIntervals(cmd *cobra.Command, args []string) error {
 			fmt.Println("No intervals available")
 			return nil
 		}
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printIntervalTableHeader(w)
-		for _, n := range response.Intervals {
-			printInterval(w, &n)
-		}
-		w.Flush()
+		return printRows(intervalTable, response.Intervals)
 	}
 	return nil
 }
 
-func printIntervalTableHeader(w *tabwriter.Writer) {
-	if verbose {
-		fmt.Fprintln(w, "Id\tName\tInterval\tStart\tEnd")
-	} else {
-		fmt.Fprintln(w, "Name\tInterval\tStart\tEnd")
-	}
-
+// intervalTable defines the columns of tables of intervals
+var intervalTable = table{
+	columns: []column{
+		newColumn("Id", func(i *dtos.Interval) interface{} { return i.Id }),
+		newColumn("Name", func(i *dtos.Interval) interface{} { return i.Name }),
+		newColumn("Interval", func(i *dtos.Interval) interface{} { return i.Interval }),
+		newColumn("Start", func(i *dtos.Interval) interface{} { return i.Start }),
+		newColumn("End", func(i *dtos.Interval) interface{} { return i.End }),
+	},
+	defaults: []string{"Name", "Interval", "Start", "End"},
 }
 
-func printInterval(w *tabwriter.Writer, n *dtos.Interval) {
-	if verbose {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n",
-			n.Id,
-			n.Name,
-			n.Interval,
-			n.Start,
-			n.End)
-	} else {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\n",
-			n.Name,
-			n.Interval,
-			n.Start,
-			n.End)
+// resolveIntervalTimes converts the start and end flags into the format used by support-scheduler
+func resolveIntervalTimes() (err error) {
+	intervalStart, err = getIntervalTime(intervalStart)
+	if err != nil {
+		return err
 	}
+	intervalEnd, err = getIntervalTime(intervalEnd)
+	return err
 }
@@ -21,9 +21,9 @@ import (
 	jsonpkg "encoding/json"
 	"errors"
 	"fmt"
-	"os"
-	"text/tabwriter"
+	"strings"
 
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
 	"github.com/spf13/cobra"
@@ -31,12 +31,20 @@ import (
 
 var intervalActionName, intervalActionIntervalName, intervalActionAddress, intervalActionId string
 var intervalActionContent, intervalActionContentType, intervalActionAdminState string
+var intervalActionHost, intervalActionPath, intervalActionHTTPMethod, intervalActionMQTTTopic string
+var intervalActionPort, intervalActionMQTTQoS int
+
+// intervalActionTypedAddressFlags are the flags specifying the address field by field
+var intervalActionTypedAddressFlags = []string{"address-host", "address-port", "address-path", "http-method", "mqtt-topic", "mqtt-qos"}
+
+// httpMethods are the HTTP methods accepted in REST addresses
+var httpMethods = []string{"GET", "HEAD", "POST", "PUT", "PATCH", "DELETE", "TRACE", "CONNECT"}
 
 func init() {
 	var cmd = &cobra.Command{
 		Use:          "intervalaction",
-		Short:        "Get, list, update and remove interval actions [Support Scheduler]",
-		Long:         "Get, list, update and remove interval actions [Support Scheduler]",
+		Short:        "Get, list, update, remove and test interval actions [Support Scheduler]",
+		Long:         "Get, list, update, remove and test interval actions [Support Scheduler]",
 		SilenceUsage: true,
 	}
 	rootCmd.AddCommand(cmd)
@@ -45,6 +53,7 @@ func init() {
 	initGetIntervalActionByNameCommand(cmd)
 	initRmIntervalActionCommand(cmd)
 	initUpdateIntervalActionCommand(cmd)
+	initTestIntervalActionCommand(cmd)
 }
 
 // initListIntervalActionCommand implements support for the GET /intervalaction/all endpoint
@@ -59,6 +68,7 @@ func initListIntervalActionCommand(cmd *cobra.Command) {
 		SilenceUsage: true,
 	}
 	addFormatFlags(listCmd)
+	addTableFlags(listCmd)
 	addVerboseFlag(listCmd)
 	addLimitOffsetFlags(listCmd)
 	cmd.AddCommand(listCmd)
@@ -77,6 +87,7 @@ func initGetIntervalActionByNameCommand(cmd *cobra.Command) {
 	nameCmd.Flags().StringVarP(&intervalActionName, "name", "n", "", "Interval action name")
 	nameCmd.MarkFlagRequired("name")
 	addFormatFlags(nameCmd)
+	addTableFlags(nameCmd)
 	addVerboseFlag(nameCmd)
 	cmd.AddCommand(nameCmd)
 
@@ -86,25 +97,26 @@ func initGetIntervalActionByNameCommand(cmd *cobra.Command) {
 // "Adds one or more notifications to be sent."
 func initAddIntervalActionCommand(cmd *cobra.Command) {
 	var add = &cobra.Command{
-		Use:          "add",
-		Short:        "Add an interval action",
-		Long:         "Add an interval action",
-		Example:      `  edgex-cli intervalaction add -n "name01" -i "midnight" -a "{\"type\": \"REST\", \"host\": \"192.168.0.102\", \"port\": 8080, \"httpMethod\": \"GET\"}"`,
+		Use:   "add",
+		Short: "Add an interval action",
+		Long:  "Add an interval action",
+		Example: `  edgex-cli intervalaction add -n "name01" -i "midnight" -a "{\"type\": \"REST\", \"host\": \"192.168.0.102\", \"port\": 8080, \"httpMethod\": \"GET\"}"
+  edgex-cli intervalaction add -n "name02" -i "midnight" --address-host localhost --address-port 59880 --address-path /api/v2/event/age/604800000000000 --http-method DELETE
+  edgex-cli intervalaction add -n "name03" -i "hourly" --address-host localhost --address-port 1883 --mqtt-topic edgex/scheduled --mqtt-qos 1 -c "{\"ping\": true}"`,
 		RunE:         handleAddIntervalAction,
 		SilenceUsage: true,
 	}
 
 	add.Flags().StringVarP(&intervalActionName, "name", "n", "", "Interval action name")
 	add.Flags().StringVarP(&intervalActionIntervalName, "interval", "i", "", "Name of the interval associated with this action")
-	add.Flags().StringVarP(&intervalActionAddress, "address", "a", "", "JSON representation of the address information")
+	addIntervalActionAddressFlags(add)
 	add.Flags().StringVarP(&intervalActionContent, "content", "c", "", "Interval action content")
-	add.Flags().StringVarP(&intervalActionContentType, "content-type", "t", "", "Interval action content type  (i.e. text/html, application/json)")
+	add.Flags().StringVarP(&intervalActionContentType, "content-type", "t", "", "Interval action content type  (i.e. text/html, application/json), by default detected from the content")
 	add.Flags().StringVarP(&intervalActionAdminState, "admin-state", "", "UNLOCKED", "Admin state [LOCKED | UNLOCKED]")
 
 	addLabelsFlag(add)
 	add.MarkFlagRequired("name")
 	add.MarkFlagRequired("interval")
-	add.MarkFlagRequired("address")
 	cmd.AddCommand(add)
 }
 
@@ -136,9 +148,9 @@ func initUpdateIntervalActionCommand(cmd *cobra.Command) {
 	add.Flags().StringVarP(&intervalActionId, "id", "", "", "Uniquely identifies the interval action, either id or name should be specified.")
 	add.Flags().StringVarP(&intervalActionName, "name", "n", "", "Interval action name")
 	add.Flags().StringVarP(&intervalActionIntervalName, "interval", "i", "", "Name of the interval associated with this action")
-	add.Flags().StringVarP(&intervalActionAddress, "address", "a", "", "JSON representation of the address information")
+	addIntervalActionAddressFlags(add)
 	add.Flags().StringVarP(&intervalActionContent, "content", "c", "", "Interval action content")
-	add.Flags().StringVarP(&intervalActionContentType, "content-type", "t", "", "Interval action content type  (i.e. text/html, application/json)")
+	add.Flags().StringVarP(&intervalActionContentType, "content-type", "t", "", "Interval action content type  (i.e. text/html, application/json), by default detected from the content")
 	add.Flags().StringVarP(&intervalActionAdminState, "admin-state", "", "UNLOCKED", "Admin state [LOCKED | UNLOCKED]")
 
 	cmd.AddCommand(add)
@@ -162,17 +174,14 @@ func handleUpdateIntervalAction(cmd *cobra.Command, args []string) error {
 	if intervalActionIntervalName != "" {
 		intervalName = &intervalActionIntervalName
 	}
-	if intervalActionAddress != "" {
-		address = new(dtos.Address)
-		err := jsonpkg.Unmarshal([]byte(intervalActionAddress), address)
-		if err != nil {
-			err = fmt.Errorf("channels JSON object array invalid (%v)", err)
-		}
+	address, err := getIntervalActionAddress(cmd)
+	if err != nil {
 		return err
 	}
 	if intervalActionContent != "" {
 		content = &intervalActionContent
 	}
+	intervalActionContentType = getIntervalActionContentType()
 	if intervalActionContentType != "" {
 		contentType = &intervalActionContentType
 	}
@@ -202,27 +211,25 @@ func handleUpdateIntervalAction(cmd *cobra.Command, args []string) error {
 }
 
 func handleAddIntervalAction(cmd *cobra.Command, args []string) error {
-	var address dtos.Address
-
 	client := getSupportSchedulerService().GetIntervalActionClient()
 	err := validateAdminState(intervalActionAdminState)
 	if err != nil {
 		return err
 	}
-	if intervalActionAddress != "" {
-		err = jsonpkg.Unmarshal([]byte(intervalActionAddress), &address)
-		if err != nil {
-			err = fmt.Errorf("channels JSON object array invalid (%v)", err)
-			return err
-		}
+	address, err := getIntervalActionAddress(cmd)
+	if err != nil {
+		return err
+	}
+	if address == nil {
+		return errors.New("an address must be specified using --address or --address-host and --address-port")
 	}
 
 	var req = requests.NewAddIntervalActionRequest(dtos.IntervalAction{
 		Name:         intervalActionName,
 		IntervalName: intervalActionIntervalName,
-		Address:      address,
+		Address:      *address,
 		Content:      intervalActionContent,
-		ContentType:  intervalActionContentType,
+		ContentType:  getIntervalActionContentType(),
 
 		AdminState: intervalActionAdminState})
 	response, err := client.Add(context.Background(), []requests.AddIntervalActionRequest{req})
@@ -234,6 +241,91 @@ func handleAddIntervalAction(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+func addIntervalActionAddressFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&intervalActionAddress, "address", "a", "", "JSON representation of the address information")
+	cmd.Flags().StringVarP(&intervalActionHost, "address-host", "", "", "Host of the REST or MQTT address")
+	cmd.Flags().IntVarP(&intervalActionPort, "address-port", "", 0, "Port of the REST or MQTT address")
+	cmd.Flags().StringVarP(&intervalActionPath, "address-path", "", "", "Path of the REST address, e.g. /api/v2/ping")
+	cmd.Flags().StringVarP(&intervalActionHTTPMethod, "http-method", "", "POST", "HTTP method of the REST address")
+	cmd.Flags().StringVarP(&intervalActionMQTTTopic, "mqtt-topic", "", "", "Topic of the MQTT address, which makes the address an MQTT address")
+	cmd.Flags().IntVarP(&intervalActionMQTTQoS, "mqtt-qos", "", 0, "QoS of the MQTT address [0 | 1 | 2]")
+}
+
+// getIntervalActionAddress returns the address given using either the --address JSON or
+// the typed address flags, or nil if none was given
+func getIntervalActionAddress(cmd *cobra.Command) (*dtos.Address, error) {
+	typed := false
+	for _, name := range intervalActionTypedAddressFlags {
+		typed = typed || cmd.Flags().Changed(name)
+	}
+
+	if intervalActionAddress != "" {
+		if typed {
+			return nil, errors.New("--address cannot be combined with the typed address flags")
+		}
+		var address dtos.Address
+		if err := jsonpkg.Unmarshal([]byte(intervalActionAddress), &address); err != nil {
+			return nil, fmt.Errorf("address JSON object invalid (%v)", err)
+		}
+		return &address, nil
+	}
+	if !typed {
+		return nil, nil
+	}
+
+	if intervalActionHost == "" {
+		return nil, errors.New("--address-host must be specified")
+	}
+	if intervalActionPort < 1 || intervalActionPort > 65535 {
+		return nil, errors.New("--address-port must be between 1 and 65535")
+	}
+
+	var address dtos.Address
+	if cmd.Flags().Changed("mqtt-topic") || cmd.Flags().Changed("mqtt-qos") {
+		if cmd.Flags().Changed("address-path") || cmd.Flags().Changed("http-method") {
+			return nil, errors.New("--address-path and --http-method cannot be used with MQTT addresses")
+		}
+		if intervalActionMQTTTopic == "" {
+			return nil, errors.New("--mqtt-topic must be specified for MQTT addresses")
+		}
+		if intervalActionMQTTQoS < 0 || intervalActionMQTTQoS > 2 {
+			return nil, errors.New("--mqtt-qos must be 0, 1 or 2")
+		}
+		address = dtos.NewMQTTAddress(intervalActionHost, intervalActionPort, "edgex-cli", intervalActionMQTTTopic)
+		address.QoS = intervalActionMQTTQoS
+	} else {
+		method := strings.ToUpper(intervalActionHTTPMethod)
+		valid := false
+		for _, m := range httpMethods {
+			valid = valid || m == method
+		}
+		if !valid {
+			return nil, fmt.Errorf("--http-method must be one of %s", strings.Join(httpMethods, ", "))
+		}
+		if intervalActionPath != "" && !strings.HasPrefix(intervalActionPath, "/") {
+			return nil, errors.New("--address-path must start with /")
+		}
+		address = dtos.NewRESTAddress(intervalActionHost, intervalActionPort, method)
+		address.Path = intervalActionPath
+	}
+	if err := validateAddress(address); err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+// getIntervalActionContentType returns the content type flag, or when it isn't given,
+// the content type detected from the content
+func getIntervalActionContentType() string {
+	if intervalActionContentType != "" || intervalActionContent == "" {
+		return intervalActionContentType
+	}
+	if jsonpkg.Valid([]byte(intervalActionContent)) {
+		return common.ContentTypeJSON
+	}
+	return common.ContentTypeText
+}
+
 func handleRmIntervalAction(cmd *cobra.Command, args []string) error {
 	client := getSupportSchedulerService().GetIntervalActionClient()
 
@@ -258,10 +350,7 @@ func handleGetIntervalActionByName(cmd *cobra.Command, args []string) error {
 
 		fmt.Println(string(result))
 	} else {
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printIntervalActionTableHeader(w)
-		printIntervalAction(w, &response.Action)
-		w.Flush()
+		return printRows(intervalActionTable, []dtos.IntervalAction{response.Action})
 	}
 	return nil
 }
@@ -285,42 +374,23 @@ func handleListIntervalActions(cmd *cobra.Command, args []string) error {
 			fmt.Println("No interval actions available")
 			return nil
 		}
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printIntervalActionTableHeader(w)
-		for _, n := range response.Actions {
-			printIntervalAction(w, &n)
-		}
-		w.Flush()
+		return printRows(intervalActionTable, response.Actions)
 	}
 	return nil
 }
 
-func printIntervalActionTableHeader(w *tabwriter.Writer) {
-	if verbose {
-		fmt.Fprintln(w, "Id\tName\tInterval\tAddress\tContent\tContentType\tAdminState\tCreated\tUpdated")
-	} else {
-		fmt.Fprintln(w, "Name\tInterval\tAddress\tContent\tContentType")
-	}
-}
-
-func printIntervalAction(w *tabwriter.Writer, n *dtos.IntervalAction) {
-	if verbose {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			n.Id,
-			n.Name,
-			n.IntervalName,
-			n.Address,
-			n.Content,
-			n.ContentType,
-			n.AdminState,
-			getRFC822Time(n.Created),
-			getRFC822Time(n.Modified))
-	} else {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n",
-			n.Name,
-			n.IntervalName,
-			n.Address,
-			n.Content,
-			n.ContentType)
-	}
+// intervalActionTable defines the columns of tables of interval actions
+var intervalActionTable = table{
+	columns: []column{
+		newColumn("Id", func(a *dtos.IntervalAction) interface{} { return a.Id }),
+		newColumn("Name", func(a *dtos.IntervalAction) interface{} { return a.Name }),
+		newColumn("Interval", func(a *dtos.IntervalAction) interface{} { return a.IntervalName }),
+		newColumn("Address", func(a *dtos.IntervalAction) interface{} { return a.Address }),
+		newColumn("Content", func(a *dtos.IntervalAction) interface{} { return a.Content }),
+		newColumn("ContentType", func(a *dtos.IntervalAction) interface{} { return a.ContentType }),
+		newColumn("AdminState", func(a *dtos.IntervalAction) interface{} { return a.AdminState }),
+		newColumn("Created", func(a *dtos.IntervalAction) interface{} { return a.Created }).withFormat(formatMillis),
+		newColumn("Updated", func(a *dtos.IntervalAction) interface{} { return a.Modified }).withFormat(formatMillis),
+	},
+	defaults: []string{"Name", "Interval", "Address", "Content", "ContentType"},
 }
@@ -0,0 +1,156 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/spf13/cobra"
+)
+
+var intervalActionTestHost string
+var intervalActionTestTimeout time.Duration
+
+func initTestIntervalActionCommand(cmd *cobra.Command) {
+	var testCmd = &cobra.Command{
+		Use:   "test [name]",
+		Short: "Perform an interval action once",
+		Long: `Perform an interval action once from the CLI and report the response, to validate it without
+waiting for its interval. Only REST actions can be tested.`,
+		Example: `  edgex-cli intervalaction test name01
+  edgex-cli intervalaction test name01 --host localhost`,
+		Args:         cobra.MaximumNArgs(1),
+		RunE:         handleTestIntervalAction,
+		SilenceUsage: true,
+	}
+	testCmd.Flags().StringVarP(&intervalActionName, "name", "n", "", "Interval action name")
+	testCmd.Flags().StringVarP(&intervalActionTestHost, "host", "", "", "Send the request to this host instead of the action's, e.g. when its host is a container name")
+	testCmd.Flags().DurationVarP(&intervalActionTestTimeout, "timeout", "", 10*time.Second, "How long to wait for a response")
+	addFormatFlags(testCmd)
+	cmd.AddCommand(testCmd)
+}
+
+// intervalActionTestResult is the outcome of performing an interval action
+type intervalActionTestResult struct {
+	Method      string `json:"method"`
+	URL         string `json:"url"`
+	StatusCode  int    `json:"statusCode"`
+	Status      string `json:"status"`
+	ContentType string `json:"contentType,omitempty"`
+	Body        string `json:"body,omitempty"`
+	Duration    string `json:"duration"`
+}
+
+func handleTestIntervalAction(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		if intervalActionName != "" {
+			return errors.New("specify the interval action name either as an argument or using --name, but not both")
+		}
+		intervalActionName = args[0]
+	}
+	if intervalActionName == "" {
+		return errors.New("an interval action name should be specified")
+	}
+
+	response, edgexErr := getSupportSchedulerService().GetIntervalActionClient().IntervalActionByName(context.Background(), intervalActionName)
+	if edgexErr != nil {
+		return edgexErr
+	}
+	action := response.Action
+	if action.Address.Type != common.REST {
+		return fmt.Errorf("testing %s interval actions is not supported, only %s actions can be tested", action.Address.Type, common.REST)
+	}
+
+	result, err := performRESTIntervalAction(action)
+	if err != nil {
+		return err
+	}
+
+	if json {
+		b, err := jsonpkg.Marshal(result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+	} else {
+		fmt.Printf("%s %s\n", result.Method, result.URL)
+		fmt.Printf("%s in %s\n", result.Status, result.Duration)
+		if result.Body != "" {
+			fmt.Println(result.Body)
+		}
+	}
+	if result.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("interval action %s failed with status %s", action.Name, result.Status)
+	}
+	return nil
+}
+
+// performRESTIntervalAction issues the request of a REST interval action the way support-scheduler does
+func performRESTIntervalAction(action dtos.IntervalAction) (intervalActionTestResult, error) {
+	address := action.Address
+	host := address.Host
+	if intervalActionTestHost != "" {
+		host = intervalActionTestHost
+	}
+	method := strings.ToUpper(address.HTTPMethod)
+	if method == "" {
+		method = http.MethodGet
+	}
+	u := url.URL{
+		Scheme: "http",
+		Host:   host + ":" + strconv.Itoa(address.Port),
+		Path:   address.Path,
+	}
+	result := intervalActionTestResult{Method: method, URL: u.String()}
+
+	req, err := http.NewRequest(method, u.String(), strings.NewReader(action.Content))
+	if err != nil {
+		return result, err
+	}
+	if action.ContentType != "" {
+		req.Header.Set(common.ContentType, action.ContentType)
+	}
+
+	client := &http.Client{Timeout: intervalActionTestTimeout}
+	started := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return result, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return result, err
+	}
+	result.Duration = time.Since(started).Round(time.Millisecond).String()
+	result.StatusCode = resp.StatusCode
+	result.Status = resp.Status
+	result.ContentType = resp.Header.Get(common.ContentType)
+	result.Body = string(body)
+	return result, nil
+}
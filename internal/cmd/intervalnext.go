@@ -0,0 +1,184 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/spf13/cobra"
+)
+
+// intervalFarFuture is how far ahead a start time has to be for it to be reported
+const intervalFarFuture = 24 * time.Hour
+
+var intervalCount int
+
+func initNextIntervalCommand(cmd *cobra.Command) {
+	var nextCmd = &cobra.Command{
+		Use:   "next [name]",
+		Short: "Show when an interval will next fire",
+		Long: `Compute the upcoming fire times of an interval, using the same semantics as support-scheduler.
+Either name an existing interval, or describe one offline using --interval, --start and --end.`,
+		Example: `  edgex-cli interval next hourly --count 5
+  edgex-cli interval next --interval 90m --start "2024-01-02 10:00" --end tomorrow`,
+		Args:         cobra.MaximumNArgs(1),
+		RunE:         handleNextInterval,
+		SilenceUsage: true,
+	}
+	nextCmd.Flags().StringVarP(&intervalName, "name", "n", "", "Interval name")
+	nextCmd.Flags().IntVarP(&intervalCount, "count", "", 10, "The number of fire times to show")
+	nextCmd.Flags().StringVarP(&intervalInterval, "interval", "i", "", "Interval string to preview offline (e.g. 100ms, 24h)")
+	nextCmd.Flags().StringVarP(&intervalStart, "start", "s", "", "Start time to preview offline ("+timeArgHelp+")")
+	nextCmd.Flags().StringVarP(&intervalEnd, "end", "e", "", "End time to preview offline ("+timeArgHelp+")")
+	addFormatFlags(nextCmd)
+	cmd.AddCommand(nextCmd)
+}
+
+// intervalSchedule is the preview of the fire times of an interval
+type intervalSchedule struct {
+	Interval  dtos.Interval `json:"interval"`
+	FireTimes []time.Time   `json:"fireTimes"`
+	Warnings  []string      `json:"warnings,omitempty"`
+}
+
+func handleNextInterval(cmd *cobra.Command, args []string) error {
+	if len(args) > 0 {
+		if intervalName != "" {
+			return errors.New("specify the interval name either as an argument or using --name, but not both")
+		}
+		intervalName = args[0]
+	}
+	if intervalCount < 1 {
+		return errors.New("count should be at least 1")
+	}
+
+	var interval dtos.Interval
+	if intervalName != "" {
+		if intervalInterval != "" || intervalStart != "" || intervalEnd != "" {
+			return errors.New("--interval, --start and --end can only be used when no interval name is given")
+		}
+		response, err := getSupportSchedulerService().GetIntervalClient().IntervalByName(context.Background(), intervalName)
+		if err != nil {
+			return err
+		}
+		interval = response.Interval
+	} else {
+		if intervalInterval == "" {
+			return errors.New("either an interval name or --interval should be specified")
+		}
+		if err := resolveIntervalTimes(); err != nil {
+			return err
+		}
+		interval = dtos.Interval{Interval: intervalInterval, Start: intervalStart, End: intervalEnd}
+	}
+
+	now := time.Now()
+	schedule, err := getIntervalSchedule(interval, now, intervalCount)
+	if err != nil {
+		return err
+	}
+
+	if json {
+		result, err := jsonpkg.Marshal(schedule)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(result))
+		return nil
+	}
+
+	if len(schedule.FireTimes) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(w, "#\tTime\tIn")
+		for i, t := range schedule.FireTimes {
+			fmt.Fprintf(w, "%d\t%s\t%v\n", i+1, t.In(timeLocation).Format(time.RFC3339), t.Sub(now).Round(time.Second))
+		}
+		w.Flush()
+	}
+	for _, warning := range schedule.Warnings {
+		fmt.Printf("Warning: %s\n", warning)
+	}
+	return nil
+}
+
+// getIntervalSchedule computes up to count fire times of the interval after now. As in
+// support-scheduler, start and end are UTC times in the format YYYYMMDD'T'HHmmss, a missing
+// start means now and a missing end means the interval never ends. The interval fires at start
+// and then every interval duration, skipping the fire times which have already passed, until end.
+func getIntervalSchedule(interval dtos.Interval, now time.Time, count int) (intervalSchedule, error) {
+	schedule := intervalSchedule{Interval: interval}
+
+	frequency, err := time.ParseDuration(interval.Interval)
+	if err != nil {
+		return schedule, fmt.Errorf("invalid interval %q: %v", interval.Interval, err)
+	}
+	if frequency <= 0 {
+		return schedule, fmt.Errorf("invalid interval %q: must be greater than zero", interval.Interval)
+	}
+
+	start := now
+	if interval.Start != "" {
+		start, err = time.Parse(intervalTimeLayout, interval.Start)
+		if err != nil {
+			return schedule, fmt.Errorf("invalid start %q: %v", interval.Start, err)
+		}
+	}
+	var end time.Time
+	if interval.End != "" {
+		end, err = time.Parse(intervalTimeLayout, interval.End)
+		if err != nil {
+			return schedule, fmt.Errorf("invalid end %q: %v", interval.End, err)
+		}
+		if !end.After(now) {
+			schedule.Warnings = append(schedule.Warnings,
+				fmt.Sprintf("the end %s is in the past, the interval will not fire again", end.In(timeLocation).Format(time.RFC3339)))
+			return schedule, nil
+		}
+		if !end.After(start) {
+			schedule.Warnings = append(schedule.Warnings, "the end is not after the start, the interval will never fire")
+			return schedule, nil
+		}
+	}
+
+	next := start
+	if next.Before(now) {
+		next = next.Add(now.Sub(start).Truncate(frequency))
+		if next.Before(now) {
+			next = next.Add(frequency)
+		}
+	}
+	if next.Sub(now) > intervalFarFuture {
+		schedule.Warnings = append(schedule.Warnings,
+			fmt.Sprintf("the interval does not fire for another %v", next.Sub(now).Round(time.Minute)))
+	}
+	for ; len(schedule.FireTimes) < count; next = next.Add(frequency) {
+		if !end.IsZero() && !next.Before(end) {
+			schedule.Warnings = append(schedule.Warnings,
+				fmt.Sprintf("the interval ends at %s", end.In(timeLocation).Format(time.RFC3339)))
+			break
+		}
+		schedule.FireTimes = append(schedule.FireTimes, next)
+	}
+	return schedule, nil
+}
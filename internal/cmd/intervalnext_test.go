@@ -0,0 +1,79 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+)
+
+func TestGetIntervalSchedule(t *testing.T) {
+	now := time.Date(2022, 3, 4, 10, 0, 30, 0, time.UTC)
+	at := func(hour int, minute int, second int) time.Time {
+		return time.Date(2022, 3, 4, hour, minute, second, 0, time.UTC)
+	}
+	tests := []struct {
+		name              string
+		interval          dtos.Interval
+		count             int
+		expectedFireTimes []time.Time
+		expectedWarnings  int
+		expectError       bool
+	}{
+		{"no start fires from now", dtos.Interval{Interval: "1m"}, 3,
+			[]time.Time{now, now.Add(time.Minute), now.Add(2 * time.Minute)}, 0, false},
+		{"past start is aligned to the interval", dtos.Interval{Interval: "15m", Start: "20220304T090000"}, 2,
+			[]time.Time{at(10, 15, 0), at(10, 30, 0)}, 0, false},
+		{"start at a fire time", dtos.Interval{Interval: "30s", Start: "20220304T100000"}, 2,
+			[]time.Time{at(10, 0, 30), at(10, 1, 0)}, 0, false},
+		{"future start", dtos.Interval{Interval: "1h", Start: "20220304T120000"}, 2,
+			[]time.Time{at(12, 0, 0), at(13, 0, 0)}, 0, false},
+		{"far future start is reported", dtos.Interval{Interval: "1h", Start: "20220310T000000"}, 1,
+			[]time.Time{time.Date(2022, 3, 10, 0, 0, 0, 0, time.UTC)}, 1, false},
+		{"end stops the schedule", dtos.Interval{Interval: "20m", Start: "20220304T100000", End: "20220304T105000"}, 5,
+			[]time.Time{at(10, 20, 0), at(10, 40, 0)}, 1, false},
+		{"end in the past", dtos.Interval{Interval: "1m", End: "20220304T090000"}, 3, nil, 1, false},
+		{"end before start", dtos.Interval{Interval: "1m", Start: "20220304T120000", End: "20220304T110000"}, 3, nil, 1, false},
+		{"invalid interval", dtos.Interval{Interval: "often"}, 3, nil, 0, true},
+		{"zero interval", dtos.Interval{Interval: "0s"}, 3, nil, 0, true},
+		{"invalid start", dtos.Interval{Interval: "1m", Start: "2022-03-04"}, 3, nil, 0, true},
+		{"invalid end", dtos.Interval{Interval: "1m", End: "tomorrow"}, 3, nil, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := getIntervalSchedule(tt.interval, now, tt.count)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %+v", schedule)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(schedule.FireTimes, tt.expectedFireTimes) {
+				t.Errorf("expected fire times %v, got %v", tt.expectedFireTimes, schedule.FireTimes)
+			}
+			if len(schedule.Warnings) != tt.expectedWarnings {
+				t.Errorf("expected %d warnings, got %v", tt.expectedWarnings, schedule.Warnings)
+			}
+		})
+	}
+}
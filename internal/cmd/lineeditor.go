@@ -0,0 +1,265 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// lineEditor reads lines from a terminal with basic editing, history and tab completion.
+// When the input is not a terminal, lines are read as they are, without editing.
+type lineEditor struct {
+	in       *bufio.Reader
+	out      io.Writer
+	terminal bool
+	history  []string
+	// complete returns the candidates for the last word of the text before the cursor
+	complete func(before string) []string
+}
+
+func newLineEditor(in *os.File, out io.Writer, complete func(string) []string) *lineEditor {
+	return &lineEditor{
+		in:       bufio.NewReader(in),
+		out:      out,
+		terminal: isTerminal(in),
+		complete: complete,
+	}
+}
+
+// isTerminal reports whether f is a terminal whose mode can be changed with stty
+func isTerminal(f *os.File) bool {
+	if runtime.GOOS == "windows" {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	_, err = exec.LookPath("stty")
+	return err == nil
+}
+
+func stty(args ...string) (string, error) {
+	return sttyOn(os.Stdin, args...)
+}
+
+func (e *lineEditor) addHistory(line string) {
+	if len(e.history) > 0 && e.history[len(e.history)-1] == line {
+		return
+	}
+	e.history = append(e.history, line)
+}
+
+// readLine prints the prompt and returns the next line entered, or io.EOF at the end of the input
+func (e *lineEditor) readLine(prompt string) (string, error) {
+	if !e.terminal {
+		return e.readPlainLine()
+	}
+	state, err := stty("-g")
+	if err != nil {
+		e.terminal = false
+		return e.readPlainLine()
+	}
+	if _, err := stty("-icanon", "-echo", "-isig", "min", "1"); err != nil {
+		e.terminal = false
+		return e.readPlainLine()
+	}
+	defer stty(state)
+
+	return e.editLine(prompt)
+}
+
+func (e *lineEditor) readPlainLine() (string, error) {
+	line, err := e.in.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	return strings.TrimRight(line, "\r\n"), err
+}
+
+func (e *lineEditor) editLine(prompt string) (string, error) {
+	var line []rune
+	cursor := 0
+	historyIndex := len(e.history)
+	edited := ""
+
+	redraw := func() {
+		fmt.Fprintf(e.out, "\r%s%s\x1b[K", prompt, string(line))
+		if back := len(line) - cursor; back > 0 {
+			fmt.Fprintf(e.out, "\x1b[%dD", back)
+		}
+	}
+	setLine := func(s string) {
+		line = []rune(s)
+		cursor = len(line)
+	}
+	redraw()
+
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+		switch r {
+		case '\r', '\n':
+			fmt.Fprint(e.out, "\r\n")
+			return string(line), nil
+		case 3: // Ctrl-C discards the line
+			fmt.Fprint(e.out, "^C\r\n")
+			line, cursor, historyIndex = nil, 0, len(e.history)
+		case 4: // Ctrl-D ends the input on an empty line, otherwise deletes
+			if len(line) == 0 {
+				fmt.Fprint(e.out, "\r\n")
+				return "", io.EOF
+			}
+			if cursor < len(line) {
+				line = append(line[:cursor], line[cursor+1:]...)
+			}
+		case 127, 8: // Backspace
+			if cursor > 0 {
+				line = append(line[:cursor-1], line[cursor:]...)
+				cursor--
+			}
+		case 1: // Ctrl-A
+			cursor = 0
+		case 5: // Ctrl-E
+			cursor = len(line)
+		case 11: // Ctrl-K
+			line = line[:cursor]
+		case 21: // Ctrl-U
+			line = line[cursor:]
+			cursor = 0
+		case 23: // Ctrl-W
+			start := cursor
+			for start > 0 && line[start-1] == ' ' {
+				start--
+			}
+			for start > 0 && line[start-1] != ' ' {
+				start--
+			}
+			line = append(line[:start], line[cursor:]...)
+			cursor = start
+		case '\t':
+			line, cursor = e.completeLine(line, cursor)
+		case 27:
+			switch e.readEscape() {
+			case "[A", "OA": // Up
+				if historyIndex > 0 {
+					if historyIndex == len(e.history) {
+						edited = string(line)
+					}
+					historyIndex--
+					setLine(e.history[historyIndex])
+				}
+			case "[B", "OB": // Down
+				if historyIndex < len(e.history) {
+					historyIndex++
+					if historyIndex == len(e.history) {
+						setLine(edited)
+					} else {
+						setLine(e.history[historyIndex])
+					}
+				}
+			case "[C", "OC": // Right
+				if cursor < len(line) {
+					cursor++
+				}
+			case "[D", "OD": // Left
+				if cursor > 0 {
+					cursor--
+				}
+			case "[H", "OH", "[1~": // Home
+				cursor = 0
+			case "[F", "OF", "[4~": // End
+				cursor = len(line)
+			case "[3~": // Delete
+				if cursor < len(line) {
+					line = append(line[:cursor], line[cursor+1:]...)
+				}
+			}
+		default:
+			if r >= ' ' {
+				line = append(line[:cursor], append([]rune{r}, line[cursor:]...)...)
+				cursor++
+			}
+		}
+		redraw()
+	}
+}
+
+// readEscape reads the rest of an escape sequence, e.g. "[A" for the up arrow
+func (e *lineEditor) readEscape() string {
+	first, _, err := e.in.ReadRune()
+	if err != nil || (first != '[' && first != 'O') {
+		return ""
+	}
+	seq := string(first)
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return seq
+		}
+		seq += string(r)
+		if r >= '@' && r <= '~' && !(r >= '0' && r <= '9') && r != ';' {
+			return seq
+		}
+	}
+}
+
+// completeLine completes the word before the cursor. A single candidate replaces the word,
+// several candidates extend it to their common prefix or, failing that, are listed.
+func (e *lineEditor) completeLine(line []rune, cursor int) ([]rune, int) {
+	if e.complete == nil {
+		return line, cursor
+	}
+	before := string(line[:cursor])
+	candidates := e.complete(before)
+	if len(candidates) == 0 {
+		return line, cursor
+	}
+	start := strings.LastIndex(before, " ") + 1
+	word := before[start:]
+
+	replacement := candidates[0]
+	if len(candidates) == 1 {
+		replacement += " "
+	} else {
+		replacement = commonPrefix(candidates)
+		if len(replacement) <= len(word) {
+			fmt.Fprintf(e.out, "\r\n%s\r\n", strings.Join(candidates, "  "))
+			return line, cursor
+		}
+	}
+	completed := []rune(before[:start] + replacement)
+	return append(completed, line[cursor:]...), len(completed)
+}
+
+func commonPrefix(values []string) string {
+	prefix := values[0]
+	for _, v := range values[1:] {
+		for !strings.HasPrefix(v, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
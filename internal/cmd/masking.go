@@ -0,0 +1,154 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+)
+
+var showSecrets bool
+
+// maskedSecret replaces the values of secrets in the output
+const maskedSecret = "*****"
+
+// secretKeyPatterns are the parts of the names of keys holding secrets, in lower case
+var secretKeyPatterns = []string{"password", "passwd", "passphrase", "token", "secret", "credential", "apikey", "api_key",
+	"privatekey", "private_key", "accesskey", "access_key", "secretkey", "signingkey", "encryptionkey"}
+
+// nonSecretKeySuffixes are the suffixes of keys naming or locating secrets rather than holding them,
+// e.g. TokenFile or SecretName
+var nonSecretKeySuffixes = []string{"file", "path", "name", "type"}
+
+// credentialsKeys are the keys of objects whose values are all secrets, in lower case
+var credentialsKeys = []string{"secretdata", "credentials"}
+
+// isSecretKey returns whether a key holds a secret
+func isSecretKey(key string) bool {
+	key = strings.ToLower(key)
+	if key == "key" {
+		return true
+	}
+	for _, suffix := range nonSecretKeySuffixes {
+		if strings.HasSuffix(key, suffix) {
+			return false
+		}
+	}
+	for _, pattern := range secretKeyPatterns {
+		if strings.Contains(key, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+func isCredentialsKey(key string) bool {
+	key = strings.ToLower(key)
+	for _, k := range credentialsKeys {
+		if key == k {
+			return true
+		}
+	}
+	return false
+}
+
+// isSecretPath returns whether the value at a path of keys is a secret, i.e. whether its key holds
+// a secret or it's within credentials. Array indexes are ignored, so the elements of an array
+// holding secrets are secrets.
+func isSecretPath(path []string) bool {
+	for i := len(path) - 1; i >= 0; i-- {
+		if _, err := strconv.Atoi(path[i]); err != nil {
+			if isSecretKey(path[i]) {
+				return true
+			}
+			break
+		}
+	}
+	for _, key := range path {
+		if isCredentialsKey(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// maskSecret returns the value to output for a secret, leaving empty values as they are
+// so that unset secrets can be told apart
+func maskSecret(value interface{}) interface{} {
+	if value == nil || value == "" || showSecrets {
+		return value
+	}
+	return maskedSecret
+}
+
+// maskSecrets returns a copy of a decoded JSON value with its secrets masked, unless --show-secrets is given
+func maskSecrets(value interface{}) interface{} {
+	return maskSecretsAt(nil, value)
+}
+
+// maskSecretsAt masks the secrets of a decoded JSON value found at a path of keys
+func maskSecretsAt(path []string, value interface{}) interface{} {
+	if showSecrets {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		masked := make(map[string]interface{}, len(v))
+		for key, child := range v {
+			masked[key] = maskSecretsAt(append(path[:len(path):len(path)], key), child)
+		}
+		return masked
+	case []interface{}:
+		masked := make([]interface{}, len(v))
+		for i, child := range v {
+			masked[i] = maskSecretsAt(path, child)
+		}
+		return masked
+	}
+	if isSecretPath(path) {
+		return maskSecret(value)
+	}
+	return value
+}
+
+// maskProtocols returns a copy of the protocol properties of a device with their secrets masked
+func maskProtocols(protocols map[string]dtos.ProtocolProperties) map[string]dtos.ProtocolProperties {
+	if showSecrets || protocols == nil {
+		return protocols
+	}
+	masked := make(map[string]dtos.ProtocolProperties, len(protocols))
+	for protocol, properties := range protocols {
+		maskedProperties := make(dtos.ProtocolProperties, len(properties))
+		for key, value := range properties {
+			if isSecretKey(key) && value != "" {
+				value = maskedSecret
+			}
+			maskedProperties[key] = value
+		}
+		masked[protocol] = maskedProperties
+	}
+	return masked
+}
+
+// maskDeviceSecrets masks the secrets in the protocol properties of devices
+func maskDeviceSecrets(devices []dtos.Device) {
+	for i := range devices {
+		devices[i].Protocols = maskProtocols(devices[i].Protocols)
+	}
+}
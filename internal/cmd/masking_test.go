@@ -0,0 +1,134 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIsSecretKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected bool
+	}{
+		{"Password", true},
+		{"password", true},
+		{"DbPasswd", true},
+		{"AccessToken", true},
+		{"ClientSecret", true},
+		{"Credentials", true},
+		{"ApiKey", true},
+		{"api_key", true},
+		{"PrivateKey", true},
+		{"Key", true},
+		{"TokenFile", false},
+		{"SecretName", false},
+		{"CredentialsPath", false},
+		{"AuthType", false},
+		{"Host", false},
+		{"KeyName", false},
+		{"Keys", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			if result := isSecretKey(tt.key); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestIsSecretPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     []string
+		expected bool
+	}{
+		{"empty path", nil, false},
+		{"secret key", []string{"Database", "Password"}, true},
+		{"other key", []string{"Database", "Host"}, false},
+		{"secret key of parent", []string{"Password", "Host"}, false},
+		{"element of secret array", []string{"Tokens", "0"}, true},
+		{"nested element of secret array", []string{"Tokens", "0", "1"}, true},
+		{"element of other array", []string{"Hosts", "0"}, false},
+		{"within credentials", []string{"InsecureSecrets", "DB", "SecretData", "username"}, true},
+		{"within credentials in lower case", []string{"credentials", "user"}, true},
+		{"credentials name", []string{"InsecureSecrets", "DB", "SecretName"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := isSecretPath(tt.path); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMaskSecretsAt(t *testing.T) {
+	config := map[string]interface{}{
+		"Host":     "localhost",
+		"Password": "secret",
+		"Token":    "",
+		"ApiKeys":  []interface{}{"key1", "key2"},
+		"InsecureSecrets": map[string]interface{}{
+			"DB": map[string]interface{}{
+				"SecretName": "redisdb",
+				"SecretData": map[string]interface{}{"username": "admin", "password": "secret"},
+			},
+		},
+	}
+	tests := []struct {
+		name        string
+		path        []string
+		value       interface{}
+		showSecrets bool
+		expected    interface{}
+	}{
+		{"configuration", nil, config, false, map[string]interface{}{
+			"Host":     "localhost",
+			"Password": maskedSecret,
+			"Token":    "",
+			"ApiKeys":  []interface{}{maskedSecret, maskedSecret},
+			"InsecureSecrets": map[string]interface{}{
+				"DB": map[string]interface{}{
+					"SecretName": "redisdb",
+					"SecretData": map[string]interface{}{"username": maskedSecret, "password": maskedSecret},
+				},
+			},
+		}},
+		{"secret value", []string{"Password"}, "secret", false, maskedSecret},
+		{"other value", []string{"Host"}, "localhost", false, "localhost"},
+		{"value within credentials", []string{"InsecureSecrets", "DB", "SecretData"}, map[string]interface{}{"username": "admin"}, false,
+			map[string]interface{}{"username": maskedSecret}},
+		{"null secret", []string{"Password"}, nil, false, nil},
+		{"shown secrets", nil, config, true, config},
+	}
+	defer func() { showSecrets = false }()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			showSecrets = tt.showSecrets
+			masked := maskSecretsAt(tt.path, tt.value)
+			if !reflect.DeepEqual(masked, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, masked)
+			}
+		})
+	}
+	if config["Password"] != "secret" {
+		t.Error("expected the configuration to be left unchanged")
+	}
+}
@@ -0,0 +1,394 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/edgex-cli/internal/service"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// The kinds of resources whose names can be completed
+const (
+	deviceNameKind         = "device"
+	profileNameKind        = "profile"
+	serviceNameKind        = "service"
+	intervalNameKind       = "interval"
+	intervalActionNameKind = "intervalaction"
+	commandNameKind        = "command"
+	watcherNameKind        = "provisionwatcher"
+	subscriptionNameKind   = "subscription"
+)
+
+// nameCacheTTL is how long fetched names are reused before being fetched again
+const nameCacheTTL = 30 * time.Second
+
+type cachedNames struct {
+	Names   []string  `json:"names"`
+	Fetched time.Time `json:"fetched"`
+}
+
+var nameCacheMutex sync.Mutex
+var nameCache = make(map[string]cachedNames)
+var nameCacheLoaded bool
+
+// getResourceNames returns the sorted names of the resources of the given kind in the running
+// deployment. For commands, names are those of the given device, or of all devices if it is empty.
+// Names are cached for nameCacheTTL, in memory and in the user's cache directory so that
+// they're shared by the short-lived processes run by shell completion scripts.
+func getResourceNames(kind string, device string) ([]string, error) {
+	s := getNameKindService(kind)
+	key := fmt.Sprintf("%s:%d/%s", s.Host, s.Port, kind)
+	if kind == commandNameKind {
+		key += "/" + device
+	}
+
+	nameCacheMutex.Lock()
+	if !nameCacheLoaded {
+		loadNameCache()
+		nameCacheLoaded = true
+	}
+	cached, ok := nameCache[key]
+	nameCacheMutex.Unlock()
+	if ok && time.Since(cached.Fetched) < nameCacheTTL {
+		return cached.Names, nil
+	}
+
+	names, err := fetchResourceNames(kind, device)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	nameCacheMutex.Lock()
+	nameCache[key] = cachedNames{Names: names, Fetched: time.Now()}
+	saveNameCache()
+	nameCacheMutex.Unlock()
+	return names, nil
+}
+
+// getNameKindService returns the service which the names of the resources of the given kind are
+// fetched from, so that names cached for different deployments aren't mixed up
+func getNameKindService(kind string) service.Service {
+	switch kind {
+	case subscriptionNameKind:
+		return getSupportNotificationsService()
+	case intervalNameKind, intervalActionNameKind:
+		return getSupportSchedulerService()
+	case commandNameKind:
+		return getCoreCommandService()
+	default:
+		return getCoreMetaDataService()
+	}
+}
+
+// getCacheFilePath returns the path of a file in the edgex-cli directory of the user's cache
+// directory, or an empty string if there is no cache directory
+func getCacheFilePath(name string) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "edgex-cli", name)
+}
+
+func getNameCachePath() string {
+	return getCacheFilePath("names.json")
+}
+
+// loadNameCache reads the names cached by previous invocations, ignoring expired and unreadable entries
+func loadNameCache() {
+	path := getNameCachePath()
+	if path == "" {
+		return
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	var cache map[string]cachedNames
+	if jsonpkg.Unmarshal(content, &cache) != nil {
+		return
+	}
+	for key, cached := range cache {
+		if time.Since(cached.Fetched) < nameCacheTTL {
+			nameCache[key] = cached
+		}
+	}
+}
+
+// saveNameCache writes the unexpired names to the cache file, on a best effort basis
+func saveNameCache() {
+	path := getNameCachePath()
+	if path == "" {
+		return
+	}
+	cache := make(map[string]cachedNames)
+	for key, cached := range nameCache {
+		if time.Since(cached.Fetched) < nameCacheTTL {
+			cache[key] = cached
+		}
+	}
+	content, err := jsonpkg.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if os.MkdirAll(filepath.Dir(path), 0700) != nil {
+		return
+	}
+	_ = os.WriteFile(path, content, 0600)
+}
+
+func fetchResourceNames(kind string, device string) ([]string, error) {
+	var names []string
+	ctx := context.Background()
+
+	switch kind {
+	case deviceNameKind:
+		client := getCoreMetaDataService().GetDeviceClient()
+		devices, err := fetchAll(func(offset int, limit int) ([]dtos.Device, uint32, error) {
+			response, err := client.AllDevices(ctx, nil, offset, limit)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Devices, response.TotalCount, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range devices {
+			names = append(names, d.Name)
+		}
+	case profileNameKind:
+		client := getCoreMetaDataService().GetDeviceProfileClient()
+		profiles, err := fetchAll(func(offset int, limit int) ([]dtos.DeviceProfile, uint32, error) {
+			response, err := client.AllDeviceProfiles(ctx, nil, offset, limit)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Profiles, response.TotalCount, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range profiles {
+			names = append(names, p.Name)
+		}
+	case serviceNameKind:
+		client := getCoreMetaDataService().GetDeviceServiceClient()
+		services, err := fetchAll(func(offset int, limit int) ([]dtos.DeviceService, uint32, error) {
+			response, err := client.AllDeviceServices(ctx, nil, offset, limit)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Services, response.TotalCount, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range services {
+			names = append(names, s.Name)
+		}
+	case watcherNameKind:
+		client := getCoreMetaDataService().GetProvisionWatcherClient()
+		watchers, err := fetchAll(func(offset int, limit int) ([]dtos.ProvisionWatcher, uint32, error) {
+			response, err := client.AllProvisionWatchers(ctx, nil, offset, limit)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.ProvisionWatchers, response.TotalCount, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, w := range watchers {
+			names = append(names, w.Name)
+		}
+	case subscriptionNameKind:
+		client := getSupportNotificationsService().GetSubscriptionClient()
+		subscriptions, err := fetchAll(func(offset int, limit int) ([]dtos.Subscription, uint32, error) {
+			response, err := client.AllSubscriptions(ctx, offset, limit)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Subscriptions, response.TotalCount, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range subscriptions {
+			names = append(names, s.Name)
+		}
+	case intervalNameKind:
+		intervals, err := getAllIntervals()
+		if err != nil {
+			return nil, err
+		}
+		for _, i := range intervals {
+			names = append(names, i.Name)
+		}
+	case intervalActionNameKind:
+		actions, err := getAllIntervalActions()
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range actions {
+			names = append(names, a.Name)
+		}
+	case commandNameKind:
+		client := getCoreCommandService().GetCommandClient()
+		if device != "" {
+			response, err := client.DeviceCoreCommandsByDeviceName(ctx, device)
+			if err != nil {
+				return nil, err
+			}
+			for _, c := range response.DeviceCoreCommand.CoreCommands {
+				names = append(names, c.Name)
+			}
+			return names, nil
+		}
+		deviceCommands, err := fetchAll(func(offset int, limit int) ([]dtos.DeviceCoreCommand, uint32, error) {
+			response, err := client.AllDeviceCoreCommands(ctx, offset, limit)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.DeviceCoreCommands, response.TotalCount, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[string]bool)
+		for _, d := range deviceCommands {
+			for _, c := range d.CoreCommands {
+				if !seen[c.Name] {
+					seen[c.Name] = true
+					names = append(names, c.Name)
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
+// getFlagNameKind returns the kind of resource named by the value of a flag of a command,
+// or an empty string if the flag doesn't name a resource
+func getFlagNameKind(cmd *cobra.Command, flag string) string {
+	group := ""
+	if cmd.HasParent() {
+		group = cmd.Parent().Name()
+	}
+	switch flag {
+	case "device":
+		return deviceNameKind
+	case "profile":
+		return profileNameKind
+	case "service":
+		return serviceNameKind
+	case "command":
+		if group == "command" {
+			return commandNameKind
+		}
+	case "interval":
+		if group == "intervalaction" {
+			return intervalNameKind
+		}
+	case "name":
+		// New resources are named by the user
+		if cmd.Name() != "add" {
+			return getGroupNameKind(group)
+		}
+	}
+	return ""
+}
+
+// getGroupNameKind returns the kind of resource managed by a command group,
+// or an empty string if its resource names can't be completed
+func getGroupNameKind(group string) string {
+	switch group {
+	case "device":
+		return deviceNameKind
+	case "deviceprofile":
+		return profileNameKind
+	case "deviceservice":
+		return serviceNameKind
+	case "interval":
+		return intervalNameKind
+	case "intervalaction":
+		return intervalActionNameKind
+	case "provisionwatcher":
+		return watcherNameKind
+	case "subscription":
+		return subscriptionNameKind
+	}
+	return ""
+}
+
+// registerNameCompletions registers completion functions for the flags and arguments naming
+// resources throughout the command tree, so that completion scripts complete live names
+func registerNameCompletions(cmd *cobra.Command) {
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		kind := getFlagNameKind(cmd, f.Name)
+		if kind == "" {
+			return
+		}
+		_ = cmd.RegisterFlagCompletionFunc(f.Name, func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			device := ""
+			if deviceFlag := c.Flags().Lookup("device"); deviceFlag != nil {
+				device = deviceFlag.Value.String()
+			}
+			return completeNames(kind, device, toComplete)
+		})
+	})
+	if strings.Contains(cmd.Use, "[name]") && cmd.HasParent() && cmd.ValidArgsFunction == nil {
+		if kind := getGroupNameKind(cmd.Parent().Name()); kind != "" {
+			cmd.ValidArgsFunction = func(c *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+				if len(args) > 0 {
+					return nil, cobra.ShellCompDirectiveNoFileComp
+				}
+				return completeNames(kind, "", toComplete)
+			}
+		}
+	}
+	for _, sub := range cmd.Commands() {
+		registerNameCompletions(sub)
+	}
+}
+
+func completeNames(kind string, device string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	names, err := getResourceNames(kind, device)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveError
+	}
+	var completions []string
+	for _, name := range names {
+		if strings.HasPrefix(name, toComplete) {
+			completions = append(completions, name)
+		}
+	}
+	return completions, cobra.ShellCompDirectiveNoFileComp
+}
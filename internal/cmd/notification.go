@@ -21,10 +21,11 @@ import (
 	jsonpkg "encoding/json"
 	"errors"
 	"fmt"
-	"os"
+	"net/http"
+	"sort"
 	"strings"
-	"text/tabwriter"
 
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/interfaces"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/responses"
@@ -42,14 +43,17 @@ func init() {
 	rootCmd.AddCommand(cmd)
 	initAddNotificationCommand(cmd)
 	initListNotificationCommand(cmd)
+	initGetNotificationByIdCommand(cmd)
 	initRmNotificationCommand(cmd)
 	initCleanupNotificationCommand(cmd)
+	initTestReceiverNotificationCommand(cmd)
 
 }
 
 var notificationCategory, notificationContent, notificationContentType, notificationDescription string
 var notificationSender, notificationSeverity, notificationStatus string
 var notificationLabel, notificationStart, notificationEnd, notificationId string
+var notificationSubscription string
 
 // initCleanupNotificationCommand implements DELETE /cleanup
 // "Deletes all notifications and the corresponding transmissions.""
@@ -79,6 +83,24 @@ func initRmNotificationCommand(cmd *cobra.Command) {
 	cmd.AddCommand(rm)
 }
 
+// initGetNotificationByIdCommand implements the GET /notification/id/{id} endpoint
+// "Returns a notification by ID."
+func initGetNotificationByIdCommand(cmd *cobra.Command) {
+	var idCmd = &cobra.Command{
+		Use:          "id",
+		Short:        "Return a notification by ID",
+		Long:         "Return a notification by ID",
+		RunE:         handleGetNotificationById,
+		SilenceUsage: true,
+	}
+	idCmd.Flags().StringVarP(&notificationId, "id", "i", "", "The ID that identifies the notification")
+	idCmd.MarkFlagRequired("id")
+	addFormatFlags(idCmd)
+	addTableFlags(idCmd)
+	addVerboseFlag(idCmd)
+	cmd.AddCommand(idCmd)
+}
+
 // initAddNotificationCommand implements the POST /notification endpoint
 // "Adds one or more notifications to be sent."
 func initAddNotificationCommand(cmd *cobra.Command) {
@@ -113,24 +135,34 @@ func initAddNotificationCommand(cmd *cobra.Command) {
 // "Allows querying of notifications by their creation timestamp within a given time range, sorted in descending order. Results are paginated.""
 // GET /notification/status/{status}
 // "Returns a paginated list of notifications with the specified status."
+// GET /notification/subscription/name/{name}
+// "Returns a paginated list of notifications associated with the specified subscription."
+//
+// When more than one filter is specified, all notifications matching each filter
+// are fetched and intersected client-side.
 func initListNotificationCommand(cmd *cobra.Command) {
 	var listCmd = &cobra.Command{
 		Use:   "list",
 		Short: "List notifications",
-		Long:  "List notifications associated with a given label, category or time range",
+		Long: `List notifications associated with a given label, category, status, subscription or time range.
+If more than one of these is specified, only notifications matching all of them are listed.`,
 		Example: `  edgex-cli notification list --start "01 jan 20 00:00 GMT" --end "01 dec 21 00:00 GMT"
   edgex-cli notification list --category "category01"
-  edgex-cli notification list --label "l01"`,
+  edgex-cli notification list --label "l01"
+  edgex-cli notification list --subscription "subscription01"
+  edgex-cli notification list --category "category01" --status NEW --start "01 jan 20 00:00 GMT" --end "01 dec 21 00:00 GMT"`,
 		RunE:         handleListNotifications,
 		SilenceUsage: true,
 	}
 	listCmd.Flags().StringVarP(&notificationCategory, "category", "c", "", "List notifications belonging to this category")
 	listCmd.Flags().StringVarP(&notificationLabel, "label", "", "", "List notifications with this label")
-	listCmd.Flags().StringVarP(&notificationStart, "start", "s", "", "List notifications from after this (RFC822) timestamp")
-	listCmd.Flags().StringVarP(&notificationEnd, "end", "e", "", "List notifications from before this (RFC822) timestamp")
+	listCmd.Flags().StringVarP(&notificationStart, "start", "s", "", "List notifications from after this time ("+timeArgHelp+")")
+	listCmd.Flags().StringVarP(&notificationEnd, "end", "e", "", "List notifications from before this time ("+timeArgHelp+")")
 	listCmd.Flags().StringVarP(&notificationStatus, "status", "", "", "List notifications with this status")
+	listCmd.Flags().StringVarP(&notificationSubscription, "subscription", "", "", "List notifications associated with this subscription")
 
 	addFormatFlags(listCmd)
+	addTableFlags(listCmd)
 	addVerboseFlag(listCmd)
 	addLimitOffsetFlags(listCmd)
 	cmd.AddCommand(listCmd)
@@ -195,33 +227,140 @@ func handleAddNotifications(cmd *cobra.Command, args []string) error {
 	return err
 }
 
-func handleListNotifications(cmd *cobra.Command, args []string) error {
+func handleGetNotificationById(cmd *cobra.Command, args []string) error {
 	client := getSupportNotificationsService().GetNotificationClient()
-	var response responses.MultiNotificationsResponse
-	var err error
+
+	response, err := client.NotificationById(context.Background(), notificationId)
+	if err != nil {
+		return err
+	}
+
+	if json {
+		result, err := jsonpkg.Marshal(response)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(string(result))
+	} else {
+		return printRows(notificationTable, []dtos.Notification{response.Notification})
+	}
+	return nil
+}
+
+// notificationQuery queries a page of notifications matching a single filter
+type notificationQuery func(offset int, limit int) (responses.MultiNotificationsResponse, error)
+
+// getNotificationQueries returns a query for each of the filters specified on the command line
+func getNotificationQueries(client interfaces.NotificationClient) ([]notificationQuery, error) {
+	var queries []notificationQuery
+	ctx := context.Background()
 
 	if notificationCategory != "" {
-		response, err = client.NotificationsByCategory(context.Background(), notificationCategory, offset, limit)
-	} else if notificationLabel != "" {
-		response, err = client.NotificationsByLabel(context.Background(), notificationLabel, offset, limit)
-	} else if notificationStatus != "" {
+		queries = append(queries, func(offset int, limit int) (responses.MultiNotificationsResponse, error) {
+			return client.NotificationsByCategory(ctx, notificationCategory, offset, limit)
+		})
+	}
+	if notificationLabel != "" {
+		queries = append(queries, func(offset int, limit int) (responses.MultiNotificationsResponse, error) {
+			return client.NotificationsByLabel(ctx, notificationLabel, offset, limit)
+		})
+	}
+	if notificationStatus != "" {
 		notificationStatus = strings.ToUpper(notificationStatus)
 		if !(notificationStatus == models.New || notificationStatus == models.Processed || notificationStatus == models.Escalated) {
-			return fmt.Errorf("status should be %s, %s or %s", models.New, models.Processed, models.Escalated)
+			return nil, fmt.Errorf("status should be %s, %s or %s", models.New, models.Processed, models.Escalated)
 		}
-		response, err = client.NotificationsByStatus(context.Background(), notificationStatus, offset, limit)
-	} else if notificationStart != "" && notificationEnd != "" {
-		start, err := getMillisTimestampFromRFC822Time(notificationStart)
+		queries = append(queries, func(offset int, limit int) (responses.MultiNotificationsResponse, error) {
+			return client.NotificationsByStatus(ctx, notificationStatus, offset, limit)
+		})
+	}
+	if notificationSubscription != "" {
+		queries = append(queries, func(offset int, limit int) (responses.MultiNotificationsResponse, error) {
+			return client.NotificationsBySubscriptionName(ctx, notificationSubscription, offset, limit)
+		})
+	}
+	if notificationStart != "" && notificationEnd != "" {
+		start, err := getMillisTimestamp(notificationStart)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		end, err := getMillisTimestampFromRFC822Time(notificationEnd)
+		end, err := getMillisTimestamp(notificationEnd)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		response, err = client.NotificationsByTimeRange(context.Background(), int(start), int(end), offset, limit)
+		queries = append(queries, func(offset int, limit int) (responses.MultiNotificationsResponse, error) {
+			return client.NotificationsByTimeRange(ctx, int(start), int(end), offset, limit)
+		})
+	}
+	return queries, nil
+}
+
+// getAllNotifications pages through all notifications returned by the query
+func getAllNotifications(query notificationQuery) ([]dtos.Notification, error) {
+	return fetchAll(func(offset int, limit int) ([]dtos.Notification, uint32, error) {
+		response, err := query(offset, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		return response.Notifications, response.TotalCount, nil
+	})
+}
+
+// getIntersectedNotifications returns the notifications matching all the queries,
+// sorted by creation time descending and paginated using the offset and limit flags
+func getIntersectedNotifications(queries []notificationQuery) (responses.MultiNotificationsResponse, error) {
+	var response responses.MultiNotificationsResponse
+
+	result, err := getAllNotifications(queries[0])
+	if err != nil {
+		return response, err
+	}
+	for _, query := range queries[1:] {
+		notifications, err := getAllNotifications(query)
+		if err != nil {
+			return response, err
+		}
+		ids := make(map[string]bool, len(notifications))
+		for _, n := range notifications {
+			ids[n.Id] = true
+		}
+		var matching []dtos.Notification
+		for _, n := range result {
+			if ids[n.Id] {
+				matching = append(matching, n)
+			}
+		}
+		result = matching
+	}
+
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Created > result[j].Created })
+	response = responses.NewMultiNotificationsResponse("", "", http.StatusOK, uint32(len(result)), nil)
+	if offset < len(result) {
+		result = result[offset:]
+		if limit >= 0 && limit < len(result) {
+			result = result[:limit]
+		}
+		response.Notifications = result
+	}
+	return response, nil
+}
+
+func handleListNotifications(cmd *cobra.Command, args []string) error {
+	client := getSupportNotificationsService().GetNotificationClient()
+	var response responses.MultiNotificationsResponse
+
+	queries, err := getNotificationQueries(client)
+	if err != nil {
+		return err
+	}
+
+	if len(queries) == 0 {
+		return errors.New("category, label, status, subscription or a timerange must be specified")
+	} else if len(queries) == 1 {
+		response, err = queries[0](offset, limit)
 	} else {
-		return errors.New("category, label, status or a timerange must be specified")
+		response, err = getIntersectedNotifications(queries)
 	}
 
 	if err != nil {
@@ -240,47 +379,25 @@ func handleListNotifications(cmd *cobra.Command, args []string) error {
 			fmt.Println("No notifications available")
 			return nil
 		}
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printNotificationTableHeader(w)
-		for _, n := range response.Notifications {
-			printNotification(w, &n)
-		}
-		w.Flush()
+		return printRows(notificationTable, response.Notifications)
 	}
 	return nil
 }
 
-func printNotificationTableHeader(w *tabwriter.Writer) {
-	if verbose {
-		fmt.Fprintln(w, "Id\tCategory\tContent\tContentType\tCreated\tDescription\tLabels\tModified\tSender\tSeverity\tStatus")
-	} else {
-		fmt.Fprintln(w, "Category\tContent\tDescription\tLabels\tSender\tSeverity\tStatus")
-	}
-
-}
-
-func printNotification(w *tabwriter.Writer, n *dtos.Notification) {
-	if verbose {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			n.Id,
-			n.Category,
-			n.Content,
-			n.ContentType,
-			getRFC822Time(n.Created),
-			n.Description,
-			n.Labels,
-			getRFC822Time(n.Modified),
-			n.Sender,
-			n.Severity,
-			n.Status)
-	} else {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			n.Category,
-			n.Content,
-			n.Description,
-			n.Labels,
-			n.Sender,
-			n.Severity,
-			n.Status)
-	}
+// notificationTable defines the columns of tables of notifications
+var notificationTable = table{
+	columns: []column{
+		newColumn("Id", func(n *dtos.Notification) interface{} { return n.Id }),
+		newColumn("Category", func(n *dtos.Notification) interface{} { return n.Category }),
+		newColumn("Content", func(n *dtos.Notification) interface{} { return n.Content }),
+		newColumn("ContentType", func(n *dtos.Notification) interface{} { return n.ContentType }),
+		newColumn("Created", func(n *dtos.Notification) interface{} { return n.Created }).withFormat(formatMillis),
+		newColumn("Description", func(n *dtos.Notification) interface{} { return n.Description }),
+		newColumn("Labels", func(n *dtos.Notification) interface{} { return n.Labels }),
+		newColumn("Modified", func(n *dtos.Notification) interface{} { return n.Modified }).withFormat(formatMillis),
+		newColumn("Sender", func(n *dtos.Notification) interface{} { return n.Sender }),
+		newColumn("Severity", func(n *dtos.Notification) interface{} { return n.Severity }),
+		newColumn("Status", func(n *dtos.Notification) interface{} { return n.Status }),
+	},
+	defaults: []string{"Category", "Content", "Description", "Labels", "Sender", "Severity", "Status"},
 }
@@ -0,0 +1,256 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/spf13/cobra"
+)
+
+var receiverPort int
+var receiverHost, receiverPath, receiverCategory, receiverContent string
+var receiverNoSubscription, receiverNoSend bool
+var receiverTimeout time.Duration
+
+// initTestReceiverNotificationCommand starts a local webhook receiver used to
+// check that notifications are delivered to REST subscriptions
+func initTestReceiverNotificationCommand(cmd *cobra.Command) {
+	var receiverCmd = &cobra.Command{
+		Use:   "test-receiver",
+		Short: "Receive notifications on a local webhook to test delivery",
+		Long: `Start a local HTTP server and print the notifications delivered to it.
+Unless told otherwise, a temporary REST subscription pointing at the receiver is created,
+a test notification is sent and the resulting transmissions are shown. The content of
+the test notification ends with its ID, which identifies its delivery among others. The
+temporary subscription is removed when the command exits.`,
+		Example: `  edgex-cli notification test-receiver --port 7770
+  edgex-cli notification test-receiver --host 172.17.0.1 --category alerts --no-send --timeout 0`,
+		RunE:         handleTestReceiverNotification,
+		SilenceUsage: true,
+	}
+	receiverCmd.Flags().IntVarP(&receiverPort, "port", "p", 7770, "Port the receiver listens on")
+	receiverCmd.Flags().StringVarP(&receiverHost, "host", "", "localhost", "Host name support-notifications uses to reach the receiver")
+	receiverCmd.Flags().StringVarP(&receiverPath, "path", "", "/", "Path the receiver accepts notifications on")
+	receiverCmd.Flags().StringVarP(&receiverCategory, "category", "", "edgex-cli-test", "Category of the temporary subscription and the test notification")
+	receiverCmd.Flags().StringVarP(&receiverContent, "content", "c", "edgex-cli test notification", "Content of the test notification")
+	receiverCmd.Flags().BoolVarP(&receiverNoSubscription, "no-subscription", "", false, "Don't create a temporary subscription")
+	receiverCmd.Flags().BoolVarP(&receiverNoSend, "no-send", "", false, "Don't send a test notification, just print what is received")
+	receiverCmd.Flags().DurationVarP(&receiverTimeout, "timeout", "t", 30*time.Second, "How long to wait for notifications, 0 waits until interrupted")
+	addVerboseFlag(receiverCmd)
+	cmd.AddCommand(receiverCmd)
+}
+
+// receivedNotification is a request received by the test receiver
+type receivedNotification struct {
+	received    time.Time
+	method      string
+	contentType string
+	body        string
+}
+
+func handleTestReceiverNotification(cmd *cobra.Command, args []string) error {
+	if !strings.HasPrefix(receiverPath, "/") {
+		receiverPath = "/" + receiverPath
+	}
+
+	// handle interrupts from the start so that the temporary subscription is always removed
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	listener, err := net.Listen("tcp", ":"+strconv.Itoa(receiverPort))
+	if err != nil {
+		return err
+	}
+	received := make(chan receivedNotification, 16)
+	// stopped is closed when the command no longer waits for notifications,
+	// so that late deliveries don't block their handlers
+	stopped := make(chan struct{})
+	defer close(stopped)
+	mux := http.NewServeMux()
+	mux.HandleFunc(receiverPath, func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		n := receivedNotification{
+			received:    time.Now(),
+			method:      r.Method,
+			contentType: r.Header.Get("Content-Type"),
+			body:        string(body),
+		}
+		select {
+		case received <- n:
+		case <-stopped:
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+	defer server.Close()
+	fmt.Printf("Listening on :%d%s\n", receiverPort, receiverPath)
+
+	if !receiverNoSubscription {
+		name, err := addTestReceiverSubscription()
+		if err != nil {
+			return err
+		}
+		defer removeTestReceiverSubscription(name)
+	}
+
+	var notificationId string
+	if !receiverNoSend {
+		notificationId, err = sendTestNotification()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Sent notification %s\n", notificationId)
+	}
+
+	var timeout <-chan time.Time
+	if receiverTimeout > 0 {
+		timeout = time.After(receiverTimeout)
+	}
+
+	delivered := false
+	for done := false; !done; {
+		select {
+		case n := <-received:
+			fmt.Printf("[%s] %s %s (%s)\n%s\n", n.received.Format(time.RFC3339), n.method, receiverPath, n.contentType, n.body)
+			// when testing with our own notification, its delivery is all we wait for
+			if notificationId != "" && strings.Contains(n.body, notificationId) {
+				delivered = true
+				done = true
+			}
+		case <-timeout:
+			done = true
+		case <-interrupt:
+			done = true
+		}
+	}
+
+	if notificationId != "" {
+		if !delivered {
+			fmt.Println("The test notification was not received before the timeout")
+		}
+		if err := printTestNotificationTransmissions(notificationId); err != nil {
+			return err
+		}
+		if !delivered {
+			return errors.New("the test notification was not delivered to the receiver")
+		}
+	}
+	return nil
+}
+
+// addTestReceiverSubscription creates a temporary REST subscription pointing at the receiver
+func addTestReceiverSubscription() (string, error) {
+	name := fmt.Sprintf("edgex-cli-test-receiver-%d", time.Now().Unix())
+	address := dtos.NewRESTAddress(receiverHost, receiverPort, http.MethodPost)
+	address.Path = receiverPath
+	if err := validateAddress(address); err != nil {
+		return "", err
+	}
+
+	req := requests.NewAddSubscriptionRequest(dtos.Subscription{
+		Name:           name,
+		Channels:       []dtos.Address{address},
+		Receiver:       "edgex-cli",
+		Categories:     []string{receiverCategory},
+		Description:    "Temporary subscription created by edgex-cli notification test-receiver",
+		ResendInterval: "1h",
+		AdminState:     models.Unlocked,
+	})
+	response, err := getSupportNotificationsService().GetSubscriptionClient().Add(context.Background(), []requests.AddSubscriptionRequest{req})
+	if err != nil {
+		return "", err
+	}
+	if len(response) > 0 && response[0].StatusCode >= http.StatusBadRequest {
+		return "", fmt.Errorf("unable to create subscription %s: %s", name, response[0].Message)
+	}
+	fmt.Printf("Created subscription %s\n", name)
+	return name, nil
+}
+
+func removeTestReceiverSubscription(name string) {
+	_, err := getSupportNotificationsService().GetSubscriptionClient().DeleteSubscriptionByName(context.Background(), name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to remove subscription %s: %v\n", name, err)
+		return
+	}
+	fmt.Printf("Removed subscription %s\n", name)
+}
+
+// sendTestNotification sends a notification in the receiver's category, returning its ID
+func sendTestNotification() (string, error) {
+	notification := dtos.NewNotification(nil, receiverCategory, "", "edgex-cli", models.Normal)
+	// REST channels deliver the content only, so it carries the ID to recognize the delivery
+	notification.Content = fmt.Sprintf("%s (%s)", receiverContent, notification.Id)
+	notification.ContentType = "text/plain"
+	req := requests.NewAddNotificationRequest(notification)
+	response, err := getSupportNotificationsService().GetNotificationClient().SendNotification(context.Background(), []requests.AddNotificationRequest{req})
+	if err != nil {
+		return "", err
+	}
+	if len(response) == 0 {
+		return "", errors.New("no response when sending the test notification")
+	}
+	if response[0].StatusCode >= http.StatusBadRequest {
+		return "", fmt.Errorf("unable to send the test notification: %s", response[0].Message)
+	}
+	return notification.Id, nil
+}
+
+// printTestNotificationTransmissions prints the transmissions recorded for the test notification
+func printTestNotificationTransmissions(notificationId string) error {
+	client := getSupportNotificationsService().GetTransmissionClient()
+
+	// the transmission is recorded after the delivery attempt completes, so allow a little time for it
+	var transmissions []dtos.Transmission
+	for attempt := 0; attempt < 5; attempt++ {
+		response, err := client.TransmissionsByNotificationId(context.Background(), notificationId, 0, -1)
+		if err != nil {
+			return err
+		}
+		transmissions = response.Transmissions
+		if len(transmissions) > 0 {
+			break
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+
+	if len(transmissions) == 0 {
+		fmt.Println("No transmissions recorded for the test notification")
+		return nil
+	}
+	return printRows(transmissionTable, transmissions)
+}
@@ -21,8 +21,6 @@ import (
 	jsonpkg "encoding/json"
 	"errors"
 	"fmt"
-	"os"
-	"text/tabwriter"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
@@ -78,6 +76,7 @@ func initListProvisionWatcherCommand(cmd *cobra.Command) {
 
 	cmd.AddCommand(listCmd)
 	addFormatFlags(listCmd)
+	addTableFlags(listCmd)
 	addVerboseFlag(listCmd)
 	addLimitOffsetFlags(listCmd)
 	addLabelsFlag(listCmd)
@@ -150,6 +149,7 @@ func initGetProvisionWatcherByNameCommand(cmd *cobra.Command) {
 	nameCmd.Flags().StringVarP(&provisionWatcherName, "name", "n", "", "Provision watcher name")
 	nameCmd.MarkFlagRequired("name")
 	addFormatFlags(nameCmd)
+	addTableFlags(nameCmd)
 	addVerboseFlag(nameCmd)
 	cmd.AddCommand(nameCmd)
 
@@ -180,10 +180,7 @@ func handleGetProvisionWatcherByName(cmd *cobra.Command, args []string) error {
 
 		fmt.Println(string(result))
 	} else {
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printProvisionWatcherTableHeader(w)
-		printProvisionWatcher(w, &response.ProvisionWatcher)
-		w.Flush()
+		return printRows(provisionWatcherTable, []dtos.ProvisionWatcher{response.ProvisionWatcher})
 	}
 	return nil
 }
@@ -207,12 +204,7 @@ func handleListProvisionWatchers(cmd *cobra.Command, args []string) error {
 			fmt.Println("No provision watchers available")
 			return nil
 		}
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printProvisionWatcherTableHeader(w)
-		for _, p := range response.ProvisionWatchers {
-			printProvisionWatcher(w, &p)
-		}
-		w.Flush()
+		return printRows(provisionWatcherTable, response.ProvisionWatchers)
 	}
 	return nil
 }
@@ -312,33 +304,18 @@ func getProvisonWatcherAttributes() (identifiers map[string]string, labels []str
 	return
 }
 
-func printProvisionWatcherTableHeader(w *tabwriter.Writer) {
-	if verbose {
-		fmt.Fprintln(w, "Id\tName\tServiceName\tProfileName\tAdminState\tLabels\tIdentifiers\tBlockingIdentifiers\tAutoEvents")
-	} else {
-		fmt.Fprintln(w, "Name\tServiceName\tProfileName\tLabels\tIdentifiers")
-	}
-
-}
-
-func printProvisionWatcher(w *tabwriter.Writer, d *dtos.ProvisionWatcher) {
-	if verbose {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			d.Id,
-			d.Name,
-			d.ServiceName,
-			d.ProfileName,
-			d.AdminState,
-			d.Labels,
-			d.Identifiers,
-			d.BlockingIdentifiers,
-			d.AutoEvents)
-	} else {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n",
-			d.Name,
-			d.ServiceName,
-			d.ProfileName,
-			d.Labels,
-			d.Identifiers)
-	}
+// provisionWatcherTable defines the columns of tables of provision watchers
+var provisionWatcherTable = table{
+	columns: []column{
+		newColumn("Id", func(w *dtos.ProvisionWatcher) interface{} { return w.Id }),
+		newColumn("Name", func(w *dtos.ProvisionWatcher) interface{} { return w.Name }),
+		newColumn("ServiceName", func(w *dtos.ProvisionWatcher) interface{} { return w.ServiceName }),
+		newColumn("ProfileName", func(w *dtos.ProvisionWatcher) interface{} { return w.ProfileName }),
+		newColumn("AdminState", func(w *dtos.ProvisionWatcher) interface{} { return w.AdminState }),
+		newColumn("Labels", func(w *dtos.ProvisionWatcher) interface{} { return w.Labels }),
+		newColumn("Identifiers", func(w *dtos.ProvisionWatcher) interface{} { return w.Identifiers }),
+		newColumn("BlockingIdentifiers", func(w *dtos.ProvisionWatcher) interface{} { return w.BlockingIdentifiers }),
+		newColumn("AutoEvents", func(w *dtos.ProvisionWatcher) interface{} { return w.AutoEvents }),
+	},
+	defaults: []string{"Name", "ServiceName", "ProfileName", "Labels", "Identifiers"},
 }
@@ -20,21 +20,30 @@ import (
 	"context"
 	jsonpkg "encoding/json"
 	"fmt"
+	"mime"
 	"os"
-	"text/tabwriter"
-	"time"
+	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/clients/interfaces"
+	edgexCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/responses"
 	"github.com/spf13/cobra"
 )
 
-var readingDevice string
+var readingDevice, readingResource, readingStart, readingEnd string
 var readingLimit, readingOffset int
 
 func init() {
 	readingCmd := initReadingCommand()
 	initListReadingCommand(readingCmd)
 	initCountReadingCommand(readingCmd)
+	initExportReadingCommand(readingCmd)
+	initStatsReadingCommand(readingCmd)
+	initPlotReadingCommand(readingCmd)
 }
 
 func initReadingCommand() *cobra.Command {
@@ -50,30 +59,43 @@ func initReadingCommand() *cobra.Command {
 
 func initListReadingCommand(cmd *cobra.Command) {
 	var listCmd = &cobra.Command{
-		Use:          "list",
-		Short:        "List all readings",
-		Long:         `List all readings, optionally specifying a limit and offset`,
+		Use:   "list",
+		Short: "List all readings",
+		Long:  `List all readings, optionally filtered by device name, resource name or time range and specifying a limit and offset`,
+		Example: `  edgex-cli reading list --device "Random-Integer-Device" --resource "Int8"
+  edgex-cli reading list --resource "Int8" --start -1h
+  edgex-cli reading list --start "2022-11-01T00:00:00Z" --end "2022-11-02T00:00:00Z"`,
 		RunE:         handleListReadings,
 		SilenceUsage: true,
 	}
 	listCmd.Flags().IntVarP(&readingLimit, "limit", "l", 50, "The number of items to return. Specifying -1 will return all remaining items")
 	listCmd.Flags().IntVarP(&readingOffset, "offset", "o", 0, "The number of items to skip")
+	listCmd.Flags().StringVarP(&readingDevice, "device", "d", "", "List readings from this device")
+	addReadingFilterFlags(listCmd)
 	cmd.AddCommand(listCmd)
 	addFormatFlags(listCmd)
+	addTableFlags(listCmd)
 	addVerboseFlag(listCmd)
 
 }
 
+func addReadingFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&readingResource, "resource", "r", "", "Only include readings of this resource")
+	cmd.Flags().StringVarP(&readingStart, "start", "", "", "Only include readings created after this time ("+timeArgHelp+")")
+	cmd.Flags().StringVarP(&readingEnd, "end", "", "", "Only include readings created before this time ("+timeArgHelp+")")
+}
+
 func initCountReadingCommand(cmd *cobra.Command) {
 	var countCmd = &cobra.Command{
 		Use:          "count",
 		Short:        "Count available readings",
-		Long:         `Count the number of readings in core data, optionally filtering by device name`,
+		Long:         `Count the number of readings in core data, optionally filtering by device name, resource name or time range`,
 		RunE:         handleCountReadings,
 		SilenceUsage: true,
 	}
 
 	countCmd.Flags().StringVarP(&readingDevice, "device", "d", "", "Device name")
+	addReadingFilterFlags(countCmd)
 	cmd.AddCommand(countCmd)
 	addFormatFlags(countCmd)
 }
@@ -84,7 +106,12 @@ func handleCountReadings(cmd *cobra.Command, args []string) error {
 	var response common.CountResponse
 	var err error
 
-	if readingDevice != "" {
+	if readingResource != "" || readingStart != "" || readingEnd != "" {
+		// there is no count endpoint for these filters, so use the total count of a query instead
+		var readings responses.MultiReadingsResponse
+		readings, err = queryReadings(client, nil, 0, 1)
+		response = common.NewCountResponse(readings.RequestId, readings.Message, readings.StatusCode, readings.TotalCount)
+	} else if readingDevice != "" {
 		response, err = client.ReadingCountByDeviceName(context.Background(), readingDevice)
 	} else {
 		response, err = client.ReadingCount(context.Background())
@@ -102,7 +129,7 @@ func handleCountReadings(cmd *cobra.Command, args []string) error {
 
 		fmt.Println(string(result))
 	} else {
-		if eventDevice != "" {
+		if readingDevice != "" {
 			fmt.Printf("Total %s readings: %v\n", readingDevice, response.Count)
 		} else {
 			fmt.Printf("Total readings: %v\n", response.Count)
@@ -115,7 +142,7 @@ func handleListReadings(cmd *cobra.Command, args []string) error {
 	var err error
 
 	client := getCoreDataService().GetReadingClient()
-	response, err := client.AllReadings(context.Background(), readingOffset, readingLimit)
+	response, err := queryReadings(client, nil, readingOffset, readingLimit)
 	if err != nil {
 		return err
 	}
@@ -134,32 +161,148 @@ func handleListReadings(cmd *cobra.Command, args []string) error {
 			return nil
 		}
 
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		if verbose {
-			fmt.Fprintln(w, "Origin\tDeviceName\tProfileName\tValue\tValueType\tId\tMediaType\tBinaryValue")
-			for _, reading := range response.Readings {
-
-				fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-					time.Unix(0, reading.Origin).Format(time.RFC822),
-					reading.DeviceName,
-					reading.ProfileName,
-					reading.Value,
-					reading.ValueType,
-					reading.Id,
-					reading.MediaType,
-					reading.BinaryValue)
-			}
-		} else {
-			fmt.Fprintln(w, "Origin\tDevice\tProfileName\tValue\tValueType")
-			for _, reading := range response.Readings {
-				tm := time.Unix(0, reading.Origin)
-				sTime := tm.Format(time.RFC822)
-				fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%v\n",
-					sTime, reading.DeviceName, reading.ProfileName, reading.Value, reading.ValueType)
-
-			}
-		}
-		w.Flush()
+		return printRows(readingTable, response.Readings)
 	}
 	return nil
 }
+
+// queryReadings selects the core-data query matching the device and resource flags and the time range,
+// which is that of the time range flags if nil
+func queryReadings(client interfaces.ReadingClient, timeRange *readingTimeRange, offset int, limit int) (responses.MultiReadingsResponse, error) {
+	ctx := context.Background()
+	if timeRange == nil && (readingStart != "" || readingEnd != "") {
+		resolved, err := resolveReadingTimeRange(readingStart, readingEnd)
+		if err != nil {
+			return responses.MultiReadingsResponse{}, err
+		}
+		timeRange = &resolved
+	}
+	if timeRange != nil {
+		start, end := timeRange.start, timeRange.end
+		if readingDevice != "" && readingResource != "" {
+			return client.ReadingsByDeviceNameAndResourceNameAndTimeRange(ctx, readingDevice, readingResource, start, end, offset, limit)
+		} else if readingDevice != "" {
+			return client.ReadingsByDeviceNameAndResourceNamesAndTimeRange(ctx, readingDevice, nil, start, end, offset, limit)
+		} else if readingResource != "" {
+			return client.ReadingsByResourceNameAndTimeRange(ctx, readingResource, start, end, offset, limit)
+		}
+		return client.ReadingsByTimeRange(ctx, start, end, offset, limit)
+	}
+
+	if readingDevice != "" && readingResource != "" {
+		return client.ReadingsByDeviceNameAndResourceName(ctx, readingDevice, readingResource, offset, limit)
+	} else if readingDevice != "" {
+		return client.ReadingsByDeviceName(ctx, readingDevice, offset, limit)
+	} else if readingResource != "" {
+		return client.ReadingsByResourceName(ctx, readingResource, offset, limit)
+	}
+	return client.AllReadings(ctx, offset, limit)
+}
+
+// readingTimeRange is a time range of readings, in nanoseconds since the epoch
+type readingTimeRange struct {
+	start int
+	end   int
+}
+
+// resolveReadingTimeRange resolves start and end time arguments to absolute timestamps, so that
+// relative times and newly added readings do not shift the results while paging
+func resolveReadingTimeRange(start string, end string) (readingTimeRange, error) {
+	s, e, err := getNanosTimeRange(start, end)
+	if err != nil {
+		return readingTimeRange{}, err
+	}
+	return readingTimeRange{start: s, end: e}, nil
+}
+
+// fetchReadings pages through all readings matching the device and resource flags within a time range
+func fetchReadings(timeRange readingTimeRange) ([]dtos.BaseReading, error) {
+	client := getCoreDataService().GetReadingClient()
+	return fetchAll(func(offset int, limit int) ([]dtos.BaseReading, uint32, error) {
+		response, err := queryReadings(client, &timeRange, offset, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		return response.Readings, response.TotalCount, nil
+	})
+}
+
+// getReadingValue decodes the value of a reading according to its value type
+func getReadingValue(r dtos.BaseReading) (interface{}, error) {
+	switch r.ValueType {
+	case edgexCommon.ValueTypeBool:
+		return strconv.ParseBool(r.Value)
+	case edgexCommon.ValueTypeUint8, edgexCommon.ValueTypeUint16, edgexCommon.ValueTypeUint32, edgexCommon.ValueTypeUint64:
+		return strconv.ParseUint(r.Value, 10, 64)
+	case edgexCommon.ValueTypeInt8, edgexCommon.ValueTypeInt16, edgexCommon.ValueTypeInt32, edgexCommon.ValueTypeInt64:
+		return strconv.ParseInt(r.Value, 10, 64)
+	case edgexCommon.ValueTypeFloat32, edgexCommon.ValueTypeFloat64:
+		return strconv.ParseFloat(r.Value, 64)
+	case edgexCommon.ValueTypeBinary:
+		return r.BinaryValue, nil
+	case edgexCommon.ValueTypeObject:
+		return r.ObjectValue, nil
+	}
+	if strings.HasSuffix(r.ValueType, "Array") {
+		var values []interface{}
+		if err := jsonpkg.Unmarshal([]byte(r.Value), &values); err == nil {
+			return values, nil
+		}
+	}
+	return r.Value, nil
+}
+
+// saveBinaryReading writes the value of a binary reading to a file in dir, named after the
+// reading ID with an extension matching its media type, returning the name of the file
+func saveBinaryReading(dir string, r dtos.BaseReading) (string, error) {
+	ext := ".bin"
+	if extensions, err := mime.ExtensionsByType(r.MediaType); err == nil && len(extensions) > 0 {
+		ext = extensions[0]
+	}
+	name := r.Id
+	if name == "" {
+		name = fmt.Sprintf("%s-%s-%d", r.DeviceName, r.ResourceName, r.Origin)
+	}
+	fileName := filepath.Join(dir, name+ext)
+	if err := os.WriteFile(fileName, r.BinaryValue, 0644); err != nil {
+		return "", err
+	}
+	return fileName, nil
+}
+
+// getNumericReadingValue returns the value of a numeric or boolean reading as a float64
+func getNumericReadingValue(r dtos.BaseReading) (float64, error) {
+	value, err := getReadingValue(r)
+	if err != nil {
+		return 0, err
+	}
+	switch v := value.(type) {
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case uint64:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	}
+	return 0, fmt.Errorf("readings of type %s are not numeric", r.ValueType)
+}
+
+// readingTable defines the columns of tables of readings
+var readingTable = table{
+	columns: []column{
+		newColumn("Origin", func(r *dtos.BaseReading) interface{} { return r.Origin }).withFormat(formatNanos),
+		newColumn("DeviceName", func(r *dtos.BaseReading) interface{} { return r.DeviceName }),
+		newColumn("ProfileName", func(r *dtos.BaseReading) interface{} { return r.ProfileName }),
+		newColumn("Value", func(r *dtos.BaseReading) interface{} { return r.Value }),
+		newColumn("ValueType", func(r *dtos.BaseReading) interface{} { return r.ValueType }),
+		newColumn("Id", func(r *dtos.BaseReading) interface{} { return r.Id }),
+		newColumn("MediaType", func(r *dtos.BaseReading) interface{} { return r.MediaType }),
+		newColumn("BinaryValue", func(r *dtos.BaseReading) interface{} { return r.BinaryValue }),
+	},
+	defaults: []string{"Origin", "DeviceName", "ProfileName", "Value", "ValueType"},
+}
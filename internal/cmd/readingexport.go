@@ -0,0 +1,237 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/csv"
+	jsonpkg "encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	edgexCommon "github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/spf13/cobra"
+)
+
+var readingExportFormat, readingExportFile, readingExportBinaryDir string
+var readingExportPageSize int
+
+var readingExportColumns = []string{"origin", "deviceName", "profileName", "resourceName", "valueType", "units", "mediaType", "value"}
+
+func initExportReadingCommand(cmd *cobra.Command) {
+	var exportCmd = &cobra.Command{
+		Use:   "export",
+		Short: "Export readings to a CSV or JSON lines file",
+		Long: `Export readings to a CSV or JSON lines file, one row per reading.
+
+Readings are fetched page by page and written as they arrive. Values are typed according
+to their value type, binary readings are written base64 encoded or, if --binary-dir is
+specified, to separate files referenced from the value column.`,
+		Example: `  edgex-cli reading export --device "Random-Integer-Device" --resource "Int8" --start -24h --file int8.csv
+  edgex-cli reading export --device "camera01" --format jsonl --binary-dir ./images --file camera01.jsonl`,
+		RunE:         handleExportReadings,
+		SilenceUsage: true,
+	}
+	exportCmd.Flags().StringVarP(&readingDevice, "device", "d", "", "Export readings from this device")
+	addReadingFilterFlags(exportCmd)
+	exportCmd.Flags().StringVarP(&readingExportFormat, "format", "", "csv", "Output format [csv | jsonl]")
+	exportCmd.Flags().StringVarP(&readingExportFile, "file", "f", "", "File to write the readings to (defaults to standard output)")
+	exportCmd.Flags().StringVarP(&readingExportBinaryDir, "binary-dir", "", "", "Directory to write binary reading values to instead of encoding them as base64")
+	exportCmd.Flags().IntVarP(&readingExportPageSize, "page-size", "", 500, "The number of readings to fetch per request")
+	cmd.AddCommand(exportCmd)
+}
+
+// readingExportWriter writes a single reading to the export output
+type readingExportWriter interface {
+	write(r dtos.BaseReading, value interface{}) error
+	flush() error
+}
+
+type csvReadingWriter struct {
+	w *csv.Writer
+}
+
+func (c csvReadingWriter) write(r dtos.BaseReading, value interface{}) error {
+	var sValue string
+	switch v := value.(type) {
+	case string:
+		sValue = v
+	case []interface{}, map[string]interface{}:
+		b, err := jsonpkg.Marshal(v)
+		if err != nil {
+			return err
+		}
+		sValue = string(b)
+	default:
+		sValue = fmt.Sprint(v)
+	}
+	return c.w.Write([]string{getExportTime(r.Origin), r.DeviceName, r.ProfileName, r.ResourceName, r.ValueType, r.Units, r.MediaType, sValue})
+}
+
+func (c csvReadingWriter) flush() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// exportedReading is a single row of a JSON lines export
+type exportedReading struct {
+	Origin       string      `json:"origin"`
+	DeviceName   string      `json:"deviceName"`
+	ProfileName  string      `json:"profileName"`
+	ResourceName string      `json:"resourceName"`
+	ValueType    string      `json:"valueType"`
+	Units        string      `json:"units,omitempty"`
+	MediaType    string      `json:"mediaType,omitempty"`
+	Value        interface{} `json:"value"`
+}
+
+type jsonLinesReadingWriter struct {
+	w *bufio.Writer
+}
+
+func (j jsonLinesReadingWriter) write(r dtos.BaseReading, value interface{}) error {
+	b, err := jsonpkg.Marshal(exportedReading{
+		Origin:       getExportTime(r.Origin),
+		DeviceName:   r.DeviceName,
+		ProfileName:  r.ProfileName,
+		ResourceName: r.ResourceName,
+		ValueType:    r.ValueType,
+		Units:        r.Units,
+		MediaType:    r.MediaType,
+		Value:        value,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = j.w.Write(append(b, '\n'))
+	return err
+}
+
+func (j jsonLinesReadingWriter) flush() error {
+	return j.w.Flush()
+}
+
+func getExportTime(origin int64) string {
+	return time.Unix(0, origin).UTC().Format(time.RFC3339Nano)
+}
+
+func handleExportReadings(cmd *cobra.Command, args []string) error {
+	if readingExportFormat != "csv" && readingExportFormat != "jsonl" {
+		return fmt.Errorf("format must be one of [csv | jsonl], not %s", readingExportFormat)
+	}
+	if readingExportPageSize < 1 {
+		return errors.New("the page size must be at least 1")
+	}
+
+	timeRange, err := resolveReadingTimeRange(readingStart, readingEnd)
+	if err != nil {
+		return err
+	}
+
+	if readingExportBinaryDir != "" {
+		if err := os.MkdirAll(readingExportBinaryDir, 0755); err != nil {
+			return err
+		}
+	}
+
+	if readingExportFile == "" {
+		_, err := exportReadings(os.Stdout, timeRange)
+		return err
+	}
+	file, err := os.Create(readingExportFile)
+	if err != nil {
+		return err
+	}
+	count, err := exportReadings(file, timeRange)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Exported %d readings to %s\n", count, readingExportFile)
+	return nil
+}
+
+// exportReadings writes the readings matching the filter flags within a time range to out,
+// and returns the number of readings written
+func exportReadings(out io.Writer, timeRange readingTimeRange) (int, error) {
+	buffered := bufio.NewWriter(out)
+
+	var writer readingExportWriter
+	if readingExportFormat == "csv" {
+		c := csv.NewWriter(buffered)
+		if err := c.Write(readingExportColumns); err != nil {
+			return 0, err
+		}
+		writer = csvReadingWriter{w: c}
+	} else {
+		writer = jsonLinesReadingWriter{w: buffered}
+	}
+
+	client := getCoreDataService().GetReadingClient()
+	count := 0
+	for offset := 0; ; {
+		response, err := queryReadings(client, &timeRange, offset, readingExportPageSize)
+		if err != nil {
+			return count, err
+		}
+		for _, r := range response.Readings {
+			value, err := getExportReadingValue(r)
+			if err != nil {
+				return count, err
+			}
+			if err := writer.write(r, value); err != nil {
+				return count, err
+			}
+		}
+		if err := writer.flush(); err != nil {
+			return count, err
+		}
+		count += len(response.Readings)
+		offset += len(response.Readings)
+		// a page may be shorter than requested when the page size exceeds the
+		// MaxResultCount of core-data, so only an empty page or the total count
+		// ends the export
+		if len(response.Readings) == 0 || (response.TotalCount > 0 && offset >= int(response.TotalCount)) {
+			break
+		}
+	}
+	return count, buffered.Flush()
+}
+
+// getExportReadingValue returns the typed value of a reading, replacing binary
+// values with either their base64 encoding or the name of a sidecar file
+func getExportReadingValue(r dtos.BaseReading) (interface{}, error) {
+	if r.ValueType != edgexCommon.ValueTypeBinary {
+		value, err := getReadingValue(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value in reading %s: %v", r.ValueType, r.Id, err)
+		}
+		return value, nil
+	}
+
+	if readingExportBinaryDir == "" {
+		return base64.StdEncoding.EncodeToString(r.BinaryValue), nil
+	}
+	return saveBinaryReading(readingExportBinaryDir, r)
+}
@@ -0,0 +1,259 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var readingPlotSince, readingPlotRefresh time.Duration
+var readingPlotFollow, readingPlotSparkline bool
+var readingPlotWidth, readingPlotHeight int
+
+var sparklineChars = []rune("▁▂▃▄▅▆▇█")
+
+func initPlotReadingCommand(cmd *cobra.Command) {
+	var plotCmd = &cobra.Command{
+		Use:   "plot",
+		Short: "Plot reading values in the terminal",
+		Long: `Plot the numeric values of a device resource as an ASCII line chart or sparkline.
+
+The chart is scaled to the terminal width unless --width is specified. The width is taken
+from the COLUMNS environment variable, then from the terminal, and defaults to 80. Readings
+with non-numeric values are skipped. With --follow the chart is redrawn until interrupted.`,
+		Example: `  edgex-cli reading plot --device "Random-Integer-Device" --resource "Int8"
+  edgex-cli reading plot --device "Random-Integer-Device" --resource "Int8" --since 1h --sparkline
+  edgex-cli reading plot --device "Random-Integer-Device" --resource "Int8" --follow`,
+		RunE:         handlePlotReadings,
+		SilenceUsage: true,
+	}
+	plotCmd.Flags().StringVarP(&readingDevice, "device", "d", "", "Device name")
+	plotCmd.Flags().StringVarP(&readingResource, "resource", "r", "", "Resource name")
+	plotCmd.Flags().VarP(newDurationValue(10*time.Minute, &readingPlotSince), "since", "", "Plot the readings created within this duration")
+	plotCmd.Flags().BoolVarP(&readingPlotFollow, "follow", "f", false, "Keep refreshing the chart")
+	plotCmd.Flags().DurationVarP(&readingPlotRefresh, "refresh", "", 2*time.Second, "Refresh interval when following")
+	plotCmd.Flags().BoolVarP(&readingPlotSparkline, "sparkline", "", false, "Show a single line sparkline instead of a chart")
+	plotCmd.Flags().IntVarP(&readingPlotWidth, "width", "", 0, "Width of the chart in characters (defaults to the terminal width)")
+	plotCmd.Flags().IntVarP(&readingPlotHeight, "height", "", 12, "Height of the chart in lines")
+	plotCmd.MarkFlagRequired("device")
+	plotCmd.MarkFlagRequired("resource")
+	cmd.AddCommand(plotCmd)
+}
+
+func handlePlotReadings(cmd *cobra.Command, args []string) error {
+	if readingPlotSince <= 0 {
+		return errors.New("--since must be a positive duration")
+	}
+	if readingPlotHeight < 2 {
+		return errors.New("the height must be at least 2")
+	}
+	if readingPlotWidth < 0 {
+		return errors.New("the width must not be negative, use 0 for the terminal width")
+	}
+
+	width := readingPlotWidth
+	if width == 0 {
+		width = getPlotWidth()
+	}
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	for {
+		chart, err := getReadingPlot(width)
+		if err != nil {
+			return err
+		}
+		if readingPlotFollow {
+			// clear the screen and move the cursor to the top left corner
+			fmt.Print("\033[H\033[2J")
+		}
+		fmt.Print(chart)
+		if !readingPlotFollow {
+			return nil
+		}
+		select {
+		case <-interrupt:
+			return nil
+		case <-time.After(readingPlotRefresh):
+		}
+	}
+}
+
+// getPlotWidth returns the width of the terminal, from the COLUMNS environment variable or
+// the terminal of stdout, or 80 if it is unknown
+func getPlotWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	if _, columns, err := getTerminalSize(os.Stdout); err == nil {
+		return columns
+	}
+	return 80
+}
+
+// getReadingPlot fetches the readings within the --since duration and renders them
+func getReadingPlot(width int) (string, error) {
+	now := time.Now()
+	readings, err := fetchReadings(readingTimeRange{start: int(now.Add(-readingPlotSince).UnixNano()), end: int(now.UnixNano())})
+	if err != nil {
+		return "", err
+	}
+
+	var samples []readingSample
+	skipped := 0
+	for _, r := range readings {
+		value, err := getNumericReadingValue(r)
+		if err != nil {
+			skipped++
+			continue
+		}
+		samples = append(samples, readingSample{origin: time.Unix(0, r.Origin), value: value})
+	}
+
+	title := fmt.Sprintf("%s/%s, last %v (%d readings", readingDevice, readingResource, readingPlotSince, len(samples))
+	if skipped > 0 {
+		title += fmt.Sprintf(", skipped %d non-numeric", skipped)
+	}
+	title += ")\n"
+	if len(samples) == 0 {
+		return title + "No numeric readings available\n", nil
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].origin.Before(samples[j].origin) })
+
+	if readingPlotSparkline {
+		columns := getPlotColumns(samples, now.Add(-readingPlotSince), now, width)
+		min, max := getPlotRange(columns)
+		return title + renderSparkline(columns, min, max) + "\n", nil
+	}
+
+	min, max := samples[0].value, samples[0].value
+	for _, s := range samples {
+		min = math.Min(min, s.value)
+		max = math.Max(max, s.value)
+	}
+	minLabel := strconv.FormatFloat(min, 'g', 6, 64)
+	maxLabel := strconv.FormatFloat(max, 'g', 6, 64)
+	labelWidth := len(minLabel)
+	if len(maxLabel) > labelWidth {
+		labelWidth = len(maxLabel)
+	}
+	if width-labelWidth-2 < 1 {
+		return "", errors.New("the terminal is too narrow to plot the readings")
+	}
+	columns := getPlotColumns(samples, now.Add(-readingPlotSince), now, width-labelWidth-2)
+	return title + renderChart(columns, min, max, readingPlotHeight, labelWidth, minLabel, maxLabel), nil
+}
+
+// getPlotColumns splits the time range into the given number of columns and returns the
+// average value of the samples in each column, or NaN for columns without samples.
+// There are no columns if n is less than 1.
+func getPlotColumns(samples []readingSample, start time.Time, end time.Time, n int) []float64 {
+	if n < 1 {
+		return nil
+	}
+	sums := make([]float64, n)
+	counts := make([]int, n)
+	span := end.Sub(start)
+	for _, s := range samples {
+		i := int(float64(s.origin.Sub(start)) / float64(span) * float64(n))
+		if i < 0 {
+			i = 0
+		} else if i >= n {
+			i = n - 1
+		}
+		sums[i] += s.value
+		counts[i]++
+	}
+	columns := make([]float64, n)
+	for i := range columns {
+		if counts[i] == 0 {
+			columns[i] = math.NaN()
+		} else {
+			columns[i] = sums[i] / float64(counts[i])
+		}
+	}
+	return columns
+}
+
+// getPlotRange returns the minimum and maximum of the values, ignoring NaN
+func getPlotRange(values []float64) (float64, float64) {
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			min = math.Min(min, v)
+			max = math.Max(max, v)
+		}
+	}
+	return min, max
+}
+
+// getPlotLevel scales the value to a level between 0 and levels-1
+func getPlotLevel(v float64, min float64, max float64, levels int) int {
+	if max == min {
+		return levels / 2
+	}
+	return int(math.Round((v - min) / (max - min) * float64(levels-1)))
+}
+
+func renderSparkline(columns []float64, min float64, max float64) string {
+	var sb strings.Builder
+	for _, v := range columns {
+		if math.IsNaN(v) {
+			sb.WriteRune(' ')
+		} else {
+			sb.WriteRune(sparklineChars[getPlotLevel(v, min, max, len(sparklineChars))])
+		}
+	}
+	return sb.String()
+}
+
+func renderChart(columns []float64, min float64, max float64, height int, labelWidth int, minLabel string, maxLabel string) string {
+	rows := make([][]byte, height)
+	for i := range rows {
+		rows[i] = []byte(strings.Repeat(" ", len(columns)))
+	}
+	for x, v := range columns {
+		if !math.IsNaN(v) {
+			rows[height-1-getPlotLevel(v, min, max, height)][x] = '*'
+		}
+	}
+
+	var sb strings.Builder
+	for i, row := range rows {
+		label := ""
+		if i == 0 {
+			label = maxLabel
+		} else if i == height-1 {
+			label = minLabel
+		}
+		fmt.Fprintf(&sb, "%*s |%s\n", labelWidth, label, row)
+	}
+	fmt.Fprintf(&sb, "%*s +%s\n", labelWidth, "", strings.Repeat("-", len(columns)))
+	return sb.String()
+}
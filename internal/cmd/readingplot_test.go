@@ -0,0 +1,58 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestGetPlotColumns(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(4 * time.Minute)
+	samples := []readingSample{
+		{origin: start, value: 1},
+		{origin: start.Add(30 * time.Second), value: 3},
+		{origin: start.Add(2 * time.Minute), value: 5},
+		{origin: end, value: 7},
+	}
+	tests := []struct {
+		name     string
+		n        int
+		expected []float64
+	}{
+		{"one column", 1, []float64{4}},
+		{"columns with and without samples", 4, []float64{2, math.NaN(), 5, 7}},
+		{"no columns", 0, nil},
+		{"negative number of columns", -3, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			columns := getPlotColumns(samples, start, end, tt.n)
+			if len(columns) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, columns)
+			}
+			for i, c := range columns {
+				if c != tt.expected[i] && !(math.IsNaN(c) && math.IsNaN(tt.expected[i])) {
+					t.Errorf("expected %v, got %v", tt.expected, columns)
+					break
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/spf13/cobra"
+)
+
+var readingStatsBucket, readingStatsGap time.Duration
+
+func initStatsReadingCommand(cmd *cobra.Command) {
+	var statsCmd = &cobra.Command{
+		Use:   "stats",
+		Short: "Show statistics of reading values",
+		Long: `Show the count, min, max, mean and percentiles of the values of a device resource,
+optionally split into time buckets, and report gaps between readings.
+
+Values are parsed according to their value type; booleans count as 0 and 1. If no start
+time is specified, the readings of the last hour are used. Gaps are reported when two
+consecutive readings, or the start or end of the time range and the nearest reading, are
+further apart than --gap. It defaults to the interval of the device's auto event for the
+resource plus a jitter tolerance of 10%.`,
+		Example: `  edgex-cli reading stats --device "Random-Integer-Device" --resource "Int8"
+  edgex-cli reading stats --device "Random-Integer-Device" --resource "Int8" --start -24h --bucket 1h`,
+		RunE:         handleStatsReadings,
+		SilenceUsage: true,
+	}
+	statsCmd.Flags().StringVarP(&readingDevice, "device", "d", "", "Device name")
+	addReadingFilterFlags(statsCmd)
+	statsCmd.Flags().DurationVarP(&readingStatsBucket, "bucket", "b", 0, "Split the statistics into buckets of this duration (e.g. 1m)")
+	statsCmd.Flags().DurationVarP(&readingStatsGap, "gap", "g", 0, "Report gaps between readings longer than this duration")
+	statsCmd.MarkFlagRequired("device")
+	statsCmd.MarkFlagRequired("resource")
+	addFormatFlags(statsCmd)
+	cmd.AddCommand(statsCmd)
+}
+
+// readingStats holds the statistics of the reading values within a bucket
+type readingStats struct {
+	Start time.Time `json:"start"`
+	Count int       `json:"count"`
+	Min   float64   `json:"min"`
+	Max   float64   `json:"max"`
+	Mean  float64   `json:"mean"`
+	P50   float64   `json:"p50"`
+	P90   float64   `json:"p90"`
+	P99   float64   `json:"p99"`
+}
+
+// readingGap is a period without readings
+type readingGap struct {
+	From     time.Time     `json:"from"`
+	To       time.Time     `json:"to"`
+	Duration time.Duration `json:"duration"`
+}
+
+type readingStatsResult struct {
+	Buckets []readingStats `json:"buckets"`
+	Gaps    []readingGap   `json:"gaps"`
+	Skipped int            `json:"skipped"`
+}
+
+// readingSample is a numeric reading value
+type readingSample struct {
+	origin time.Time
+	value  float64
+}
+
+func handleStatsReadings(cmd *cobra.Command, args []string) error {
+	start := readingStart
+	if start == "" {
+		start = "-1h"
+	}
+	timeRange, err := resolveReadingTimeRange(start, readingEnd)
+	if err != nil {
+		return err
+	}
+
+	readings, err := fetchReadings(timeRange)
+	if err != nil {
+		return err
+	}
+
+	var result readingStatsResult
+	samples := make([]readingSample, 0, len(readings))
+	for _, r := range readings {
+		value, err := getNumericReadingValue(r)
+		if err != nil {
+			result.Skipped++
+			continue
+		}
+		samples = append(samples, readingSample{origin: time.Unix(0, r.Origin), value: value})
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i].origin.Before(samples[j].origin) })
+
+	result.Buckets = getReadingStatsBuckets(samples, readingStatsBucket)
+
+	gap := readingStatsGap
+	if gap == 0 {
+		gap, err = getAutoEventGap(readingDevice, readingResource)
+		if err != nil {
+			return err
+		}
+	}
+	if gap > 0 {
+		from, to := getReadingGapRange(timeRange)
+		result.Gaps = getReadingGaps(samples, from, to, gap)
+	}
+
+	if json {
+		b, err := jsonpkg.Marshal(result)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	}
+
+	if len(samples) == 0 {
+		fmt.Println("No numeric readings available")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(w, "Start\tCount\tMin\tMax\tMean\tP50\tP90\tP99")
+	for _, b := range result.Buckets {
+		fmt.Fprintf(w, "%s\t%d\t%g\t%g\t%g\t%g\t%g\t%g\n",
+			formatRFC822Time(b.Start), b.Count, b.Min, b.Max, b.Mean, b.P50, b.P90, b.P99)
+	}
+	w.Flush()
+
+	if result.Skipped > 0 {
+		fmt.Printf("\nSkipped %d non-numeric readings\n", result.Skipped)
+	}
+
+	if gap == 0 {
+		fmt.Println("\nNo auto event found for this resource, use --gap to report gaps")
+	} else if len(result.Gaps) == 0 {
+		fmt.Printf("\nNo gaps longer than %v\n", gap)
+	} else {
+		fmt.Printf("\nGaps longer than %v:\n", gap)
+		w = tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(w, "From\tTo\tDuration")
+		for _, g := range result.Gaps {
+			fmt.Fprintf(w, "%s\t%s\t%v\n", g.From.In(timeLocation).Format(time.RFC3339), g.To.In(timeLocation).Format(time.RFC3339), g.Duration)
+		}
+		w.Flush()
+	}
+	return nil
+}
+
+// getReadingStatsBuckets groups the samples, which must be sorted by origin, into buckets
+// of the given size and calculates the statistics of each bucket. A size of 0 results in
+// a single bucket.
+func getReadingStatsBuckets(samples []readingSample, size time.Duration) []readingStats {
+	var buckets []readingStats
+	for i := 0; i < len(samples); {
+		start := samples[i].origin
+		if size > 0 {
+			start = start.Truncate(size)
+		}
+		j := i
+		for j < len(samples) && (size == 0 || samples[j].origin.Before(start.Add(size))) {
+			j++
+		}
+		buckets = append(buckets, getReadingStats(start, samples[i:j]))
+		i = j
+	}
+	return buckets
+}
+
+func getReadingStats(start time.Time, samples []readingSample) readingStats {
+	values := make([]float64, len(samples))
+	sum := 0.0
+	for i, s := range samples {
+		values[i] = s.value
+		sum += s.value
+	}
+	sort.Float64s(values)
+
+	return readingStats{
+		Start: start,
+		Count: len(values),
+		Min:   values[0],
+		Max:   values[len(values)-1],
+		Mean:  sum / float64(len(values)),
+		P50:   getPercentile(values, 50),
+		P90:   getPercentile(values, 90),
+		P99:   getPercentile(values, 99),
+	}
+}
+
+// getPercentile returns the nearest-rank percentile of the sorted values
+func getPercentile(sorted []float64, p float64) float64 {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// getReadingGapRange returns the period of a time range in which gaps are looked for,
+// ending no later than now
+func getReadingGapRange(timeRange readingTimeRange) (time.Time, time.Time) {
+	to := time.Unix(0, int64(timeRange.end))
+	if now := time.Now(); to.After(now) {
+		to = now
+	}
+	return time.Unix(0, int64(timeRange.start)), to
+}
+
+// getReadingGaps returns the periods longer than gap between consecutive samples, which
+// must be sorted by origin, and between the samples and the start and end of the time range
+func getReadingGaps(samples []readingSample, from time.Time, to time.Time, gap time.Duration) []readingGap {
+	var gaps []readingGap
+	previous := from
+	for _, s := range samples {
+		if d := s.origin.Sub(previous); d > gap {
+			gaps = append(gaps, readingGap{From: previous, To: s.origin, Duration: d})
+		}
+		previous = s.origin
+	}
+	if d := to.Sub(previous); d > gap {
+		gaps = append(gaps, readingGap{From: previous, To: to, Duration: d})
+	}
+	return gaps
+}
+
+// autoEventJitter is the fraction of the auto event interval that readings may be late
+// before the time between them is reported as a gap
+const autoEventJitter = 0.1
+
+// getAutoEventGap returns the interval of the device's auto event reading the given resource,
+// either directly or through a device command of the device's profile, plus the jitter tolerance,
+// or 0 if the resource is not read periodically
+func getAutoEventGap(deviceName string, resourceName string) (time.Duration, error) {
+	device, commands, err := getAutoEventSources(deviceName, resourceName)
+	if err != nil {
+		return 0, err
+	}
+	interval, err := getResourceAutoEventInterval(device.AutoEvents, commands, resourceName)
+	if err != nil || interval == 0 {
+		return 0, err
+	}
+	return interval + time.Duration(float64(interval)*autoEventJitter), nil
+}
+
+// getAutoEventSources returns a device and, if some of its auto events don't read the resource
+// directly, the device commands of its profile
+func getAutoEventSources(deviceName string, resourceName string) (dtos.Device, []dtos.DeviceCommand, error) {
+	ctx := context.Background()
+	response, err := getCoreMetaDataService().GetDeviceClient().DeviceByName(ctx, deviceName)
+	if err != nil {
+		return dtos.Device{}, nil, err
+	}
+	for _, autoEvent := range response.Device.AutoEvents {
+		if autoEvent.SourceName != resourceName {
+			profile, err := getCoreMetaDataService().GetDeviceProfileClient().DeviceProfileByName(ctx, response.Device.ProfileName)
+			if err != nil {
+				return dtos.Device{}, nil, err
+			}
+			return response.Device, profile.Profile.DeviceCommands, nil
+		}
+	}
+	return response.Device, nil, nil
+}
+
+// getResourceAutoEventInterval returns the shortest interval of the periodic auto events whose source
+// is either the resource or one of the device commands reading it, or 0 if there are none
+func getResourceAutoEventInterval(autoEvents []dtos.AutoEvent, commands []dtos.DeviceCommand, resourceName string) (time.Duration, error) {
+	sources := map[string]bool{resourceName: true}
+	for _, command := range commands {
+		for _, operation := range command.ResourceOperations {
+			if operation.DeviceResource == resourceName {
+				sources[command.Name] = true
+			}
+		}
+	}
+
+	var shortest time.Duration
+	for _, autoEvent := range autoEvents {
+		if !sources[autoEvent.SourceName] || autoEvent.OnChange {
+			continue
+		}
+		interval, err := time.ParseDuration(autoEvent.Interval)
+		if err != nil {
+			return 0, fmt.Errorf("invalid auto event interval %s: %v", autoEvent.Interval, err)
+		}
+		if shortest == 0 || interval < shortest {
+			shortest = interval
+		}
+	}
+	return shortest, nil
+}
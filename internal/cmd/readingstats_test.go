@@ -0,0 +1,156 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+)
+
+func TestGetPercentile(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []float64
+		p        float64
+		expected float64
+	}{
+		{"single value", []float64{7}, 50, 7},
+		{"median of odd count", []float64{1, 2, 3, 4, 5}, 50, 3},
+		{"median of even count", []float64{1, 2, 3, 4}, 50, 2},
+		{"p90 of ten values", []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 90, 9},
+		{"p99 of ten values", []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 99, 10},
+		{"p0 is the minimum", []float64{1, 2, 3}, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if actual := getPercentile(tt.values, tt.p); actual != tt.expected {
+				t.Errorf("expected %g, got %g", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetReadingStatsBuckets(t *testing.T) {
+	base := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	samples := []readingSample{
+		{base.Add(10 * time.Second), 1},
+		{base.Add(20 * time.Second), 3},
+		{base.Add(70 * time.Second), 10},
+	}
+	tests := []struct {
+		name     string
+		size     time.Duration
+		expected []readingStats
+	}{
+		{"single bucket", 0, []readingStats{
+			{Start: base.Add(10 * time.Second), Count: 3, Min: 1, Max: 10, Mean: 14.0 / 3, P50: 3, P90: 10, P99: 10},
+		}},
+		{"minute buckets", time.Minute, []readingStats{
+			{Start: base, Count: 2, Min: 1, Max: 3, Mean: 2, P50: 1, P90: 3, P99: 3},
+			{Start: base.Add(time.Minute), Count: 1, Min: 10, Max: 10, Mean: 10, P50: 10, P90: 10, P99: 10},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := getReadingStatsBuckets(samples, tt.size)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, actual)
+			}
+		})
+	}
+
+	if buckets := getReadingStatsBuckets(nil, time.Minute); len(buckets) != 0 {
+		t.Errorf("expected no buckets without samples, got %+v", buckets)
+	}
+}
+
+func TestGetReadingGaps(t *testing.T) {
+	from := time.Date(2022, 1, 1, 10, 0, 0, 0, time.UTC)
+	to := from.Add(time.Minute)
+	at := func(seconds int) time.Time { return from.Add(time.Duration(seconds) * time.Second) }
+	tests := []struct {
+		name     string
+		samples  []readingSample
+		gap      time.Duration
+		expected []readingGap
+	}{
+		{"no gaps", []readingSample{{at(5), 0}, {at(15), 0}, {at(25), 0}, {at(35), 0}, {at(45), 0}, {at(55), 0}}, 10 * time.Second, nil},
+		{"gap between readings", []readingSample{{at(5), 0}, {at(30), 0}, {at(55), 0}}, 20 * time.Second, []readingGap{
+			{From: at(5), To: at(30), Duration: 25 * time.Second},
+			{From: at(30), To: at(55), Duration: 25 * time.Second},
+		}},
+		{"gap at the start", []readingSample{{at(30), 0}, {at(40), 0}, {at(50), 0}, {at(60), 0}}, 20 * time.Second, []readingGap{
+			{From: from, To: at(30), Duration: 30 * time.Second},
+		}},
+		{"gap at the end", []readingSample{{at(0), 0}, {at(10), 0}}, 20 * time.Second, []readingGap{
+			{From: at(10), To: to, Duration: 50 * time.Second},
+		}},
+		{"no readings", nil, 20 * time.Second, []readingGap{
+			{From: from, To: to, Duration: time.Minute},
+		}},
+		{"gap equal to the limit", []readingSample{{at(0), 0}, {at(20), 0}, {at(40), 0}, {at(60), 0}}, 20 * time.Second, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := getReadingGaps(tt.samples, from, to, tt.gap)
+			if !reflect.DeepEqual(actual, tt.expected) {
+				t.Errorf("expected %+v, got %+v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestGetResourceAutoEventInterval(t *testing.T) {
+	commands := []dtos.DeviceCommand{
+		{Name: "Status", ResourceOperations: []dtos.ResourceOperation{{DeviceResource: "Temperature"}, {DeviceResource: "Humidity"}}},
+		{Name: "Mode", ResourceOperations: []dtos.ResourceOperation{{DeviceResource: "Mode"}}},
+	}
+	tests := []struct {
+		name             string
+		autoEvents       []dtos.AutoEvent
+		expectedInterval time.Duration
+		expectError      bool
+	}{
+		{"no auto events", nil, 0, false},
+		{"resource", []dtos.AutoEvent{{SourceName: "Temperature", Interval: "10s"}}, 10 * time.Second, false},
+		{"command reading the resource", []dtos.AutoEvent{{SourceName: "Status", Interval: "1m"}}, time.Minute, false},
+		{"shortest interval", []dtos.AutoEvent{{SourceName: "Status", Interval: "1m"}, {SourceName: "Temperature", Interval: "30s"}}, 30 * time.Second, false},
+		{"other sources", []dtos.AutoEvent{{SourceName: "Mode", Interval: "1s"}, {SourceName: "Pressure", Interval: "1s"}}, 0, false},
+		{"on change", []dtos.AutoEvent{{SourceName: "Temperature", Interval: "1s", OnChange: true}}, 0, false},
+		{"invalid interval", []dtos.AutoEvent{{SourceName: "Status", Interval: "often"}}, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interval, err := getResourceAutoEventInterval(tt.autoEvents, commands, "Temperature")
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %v", interval)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if interval != tt.expectedInterval {
+				t.Errorf("expected %v, got %v", tt.expectedInterval, interval)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"errors"
 	"os"
 
 	"github.com/spf13/cobra"
@@ -26,11 +27,39 @@ var rootCmd = &cobra.Command{
 	Use:       "edgex-cli",
 	Short:     "EdgeX-CLI",
 	ValidArgs: []string{"ping", "version"},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := setTimeLocation(timezone); err != nil {
+			return err
+		}
+		warnIncompatibleVersions(cmd)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().StringVarP(&timezone, "timezone", "", "Local", "Timezone used to display times and to interpret times given without a zone, e.g. UTC or Europe/London")
+	rootCmd.PersistentFlags().BoolVarP(&showSecrets, "show-secrets", "", false, "Show the values of passwords, tokens and other secrets instead of masking them")
+	rootCmd.PersistentFlags().BoolVarP(&skipVersionCheck, "skip-version-check", "", false, "Don't warn about services whose version isn't compatible with edgex-cli")
+}
+
+// exitCodeError is returned by commands which exit with a specific status code
+type exitCodeError struct {
+	code    int
+	message string
+}
+
+func (e exitCodeError) Error() string {
+	return e.message
 }
 
 // Execute the commands
 func Execute() {
+	registerNameCompletions(rootCmd)
 	if err := rootCmd.Execute(); err != nil {
+		var exitErr exitCodeError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.code)
+		}
 		os.Exit(1)
 	}
 }
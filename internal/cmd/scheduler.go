@@ -0,0 +1,186 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	var cmd = &cobra.Command{
+		Use:          "scheduler",
+		Short:        "Show the schedule of intervals and interval actions [Support Scheduler]",
+		Long:         "Show the schedule of intervals and interval actions [Support Scheduler]",
+		SilenceUsage: true,
+	}
+	rootCmd.AddCommand(cmd)
+	initShowSchedulerCommand(cmd)
+}
+
+func initShowSchedulerCommand(cmd *cobra.Command) {
+	var showCmd = &cobra.Command{
+		Use:   "show",
+		Short: "Show intervals together with their interval actions",
+		Long: `Show each interval with its frequency and time window, together with the targets and admin state
+of the interval actions it triggers. Intervals without actions and actions referring to missing
+intervals are reported.`,
+		RunE:         handleShowScheduler,
+		SilenceUsage: true,
+	}
+	addFormatFlags(showCmd)
+	cmd.AddCommand(showCmd)
+}
+
+// scheduledInterval is an interval with the interval actions it triggers
+type scheduledInterval struct {
+	Interval dtos.Interval         `json:"interval"`
+	Actions  []dtos.IntervalAction `json:"actions"`
+}
+
+// schedulerOverview joins the intervals with their interval actions
+type schedulerOverview struct {
+	Intervals       []scheduledInterval   `json:"intervals"`
+	OrphanedActions []dtos.IntervalAction `json:"orphanedActions"`
+}
+
+func handleShowScheduler(cmd *cobra.Command, args []string) error {
+	intervals, err := getAllIntervals()
+	if err != nil {
+		return err
+	}
+	actions, err := getAllIntervalActions()
+	if err != nil {
+		return err
+	}
+	overview := getSchedulerOverview(intervals, actions)
+
+	if json {
+		result, err := jsonpkg.Marshal(overview)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(result))
+		return nil
+	}
+
+	if len(overview.Intervals) == 0 && len(overview.OrphanedActions) == 0 {
+		fmt.Println("No intervals available")
+		return nil
+	}
+
+	var unused []string
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(w, "Interval\tFrequency\tStart\tEnd\tAction\tTarget\tAdminState")
+	for _, s := range overview.Intervals {
+		i := s.Interval
+		if len(s.Actions) == 0 {
+			unused = append(unused, i.Name)
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				i.Name, i.Interval, formatIntervalTime(i.Start), formatIntervalTime(i.End), "(none)", "", "")
+			continue
+		}
+		for _, a := range s.Actions {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+				i.Name, i.Interval, formatIntervalTime(i.Start), formatIntervalTime(i.End), a.Name, formatAddress(a.Address), a.AdminState)
+		}
+	}
+	w.Flush()
+
+	if len(unused) > 0 {
+		fmt.Printf("\nIntervals without actions: %d\n", len(unused))
+		for _, name := range unused {
+			fmt.Printf("  %s\n", name)
+		}
+	}
+	if len(overview.OrphanedActions) > 0 {
+		fmt.Printf("\nActions referring to missing intervals: %d\n", len(overview.OrphanedActions))
+		w = tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(w, "Action\tInterval\tTarget\tAdminState")
+		for _, a := range overview.OrphanedActions {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", a.Name, a.IntervalName, formatAddress(a.Address), a.AdminState)
+		}
+		w.Flush()
+	}
+	return nil
+}
+
+// getSchedulerOverview joins the interval actions to the intervals by interval name,
+// sorting the intervals and their actions by name
+func getSchedulerOverview(intervals []dtos.Interval, actions []dtos.IntervalAction) schedulerOverview {
+	var overview schedulerOverview
+	byName := make(map[string]*scheduledInterval, len(intervals))
+	overview.Intervals = make([]scheduledInterval, len(intervals))
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i].Name < intervals[j].Name })
+	for i, interval := range intervals {
+		overview.Intervals[i] = scheduledInterval{Interval: interval, Actions: []dtos.IntervalAction{}}
+		byName[interval.Name] = &overview.Intervals[i]
+	}
+
+	sort.Slice(actions, func(i, j int) bool { return actions[i].Name < actions[j].Name })
+	overview.OrphanedActions = []dtos.IntervalAction{}
+	for _, action := range actions {
+		if s, ok := byName[action.IntervalName]; ok {
+			s.Actions = append(s.Actions, action)
+		} else {
+			overview.OrphanedActions = append(overview.OrphanedActions, action)
+		}
+	}
+	return overview
+}
+
+func getAllIntervals() ([]dtos.Interval, error) {
+	client := getSupportSchedulerService().GetIntervalClient()
+	return fetchAll(func(offset int, limit int) ([]dtos.Interval, uint32, error) {
+		response, err := client.AllIntervals(context.Background(), offset, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		return response.Intervals, response.TotalCount, nil
+	})
+}
+
+func getAllIntervalActions() ([]dtos.IntervalAction, error) {
+	client := getSupportSchedulerService().GetIntervalActionClient()
+	return fetchAll(func(offset int, limit int) ([]dtos.IntervalAction, uint32, error) {
+		response, err := client.AllIntervalActions(context.Background(), offset, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		return response.Actions, response.TotalCount, nil
+	})
+}
+
+// formatIntervalTime renders an interval start or end time in the --timezone location
+func formatIntervalTime(t string) string {
+	if t == "" {
+		return "-"
+	}
+	result, err := time.Parse(intervalTimeLayout, t)
+	if err != nil {
+		return t
+	}
+	return formatRFC822Time(result)
+}
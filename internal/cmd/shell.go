@@ -0,0 +1,395 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const shellPrompt = "edgex> "
+const shellHistoryFile = ".edgex-cli_history"
+const shellHistorySize = 500
+
+// shellInterruptGrace is how long an interrupted command is given to stop on its own before
+// the shell returns to the prompt
+const shellInterruptGrace = time.Second
+
+var shellCmd *cobra.Command
+
+func init() {
+	shellCmd = &cobra.Command{
+		Use:   "shell",
+		Short: "Start an interactive shell",
+		Long: `Start an interactive shell running edgex-cli commands, e.g. 'device list' or 'command read -d Random-Integer-Device -c Int8'.
+
+Tab completes commands, flags and the names of devices, profiles, device services, commands,
+intervals and interval actions, which are fetched from the running deployment and cached.
+Up and down arrows recall the history, which is kept in ~/` + shellHistoryFile + `.
+Type 'history' to list it, and 'exit', 'quit' or Ctrl-D to leave the shell. Ctrl-C
+interrupts the running command; commands which don't stop on their own when interrupted,
+e.g. while waiting for an unresponsive service, are left to complete in the background.`,
+		RunE:         handleShell,
+		SilenceUsage: true,
+	}
+	rootCmd.AddCommand(shellCmd)
+}
+
+func handleShell(cmd *cobra.Command, args []string) error {
+	editor := newLineEditor(os.Stdin, os.Stdout, completeShellLine)
+	historyPath := getShellHistoryPath()
+	editor.history = loadShellHistory(historyPath)
+
+	// Interrupts are caught for the whole session so that they stop commands rather than the shell
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+
+	if editor.terminal {
+		fmt.Println("EdgeX CLI shell. Type 'help' for the list of commands, 'exit' to leave.")
+	}
+	for {
+		line, err := editor.readLine(shellPrompt)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		editor.addHistory(line)
+		saveShellHistory(historyPath, editor.history)
+
+		args, err := splitShellLine(line)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "Error:", err)
+			continue
+		}
+		switch args[0] {
+		case "exit", "quit":
+			return nil
+		case "history":
+			for i, h := range editor.history {
+				fmt.Printf("%5d  %s\n", i+1, h)
+			}
+		default:
+			runShellCommand(args, interrupt)
+		}
+	}
+}
+
+// runShellCommand runs a command line through the command tree, as if given on the command line.
+// Errors are reported by cobra. When interrupted, the command is given shellInterruptGrace to stop,
+// e.g. if it handles interrupts itself, and is otherwise left to complete in the background, as the
+// clients can't cancel their requests.
+func runShellCommand(args []string, interrupt <-chan os.Signal) {
+	target, _, err := rootCmd.Find(args)
+	if err == nil && target == shellCmd {
+		fmt.Fprintln(os.Stderr, "Error: already in a shell")
+		return
+	}
+	// Flag values persist in package variables between executions and commands read variables
+	// bound to the flags of other commands, so the flags of all commands are reset. Variables
+	// may be bound to flags with different defaults, so those of the command are reset last.
+	resetCommandFlags(rootCmd)
+	if err == nil {
+		for c := target; c != nil; c = c.Parent() {
+			resetFlags(c.Flags())
+			resetFlags(c.PersistentFlags())
+		}
+	}
+
+	// Drop the interrupts received while the line was read
+	for len(interrupt) > 0 {
+		<-interrupt
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		rootCmd.SetArgs(args)
+		_ = rootCmd.Execute()
+	}()
+	select {
+	case <-done:
+	case <-interrupt:
+		select {
+		case <-done:
+		case <-time.After(shellInterruptGrace):
+			fmt.Fprintln(os.Stderr, "\nInterrupted, the command is left to complete in the background")
+		}
+	}
+}
+
+// resetCommandFlags resets the flags of a command and of all its subcommands to their defaults
+func resetCommandFlags(cmd *cobra.Command) {
+	resetFlags(cmd.Flags())
+	resetFlags(cmd.PersistentFlags())
+	for _, sub := range cmd.Commands() {
+		resetCommandFlags(sub)
+	}
+}
+
+func resetFlags(flags *pflag.FlagSet) {
+	flags.VisitAll(func(f *pflag.Flag) {
+		if s, ok := f.Value.(pflag.SliceValue); ok {
+			_ = s.Replace(nil)
+			if f.DefValue != "[]" {
+				_ = f.Value.Set(strings.Trim(f.DefValue, "[]"))
+			}
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	})
+}
+
+// splitShellLine splits a command line into arguments, honouring single and double quotes
+// and backslash escapes
+func splitShellLine(line string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inArg = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if escaped {
+		return nil, errors.New("trailing backslash")
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// completeShellLine returns the completions of the last word of a partial command line
+func completeShellLine(before string) []string {
+	words := strings.Fields(before)
+	word := ""
+	if len(words) > 0 && !strings.HasSuffix(before, " ") {
+		word = words[len(words)-1]
+		words = words[:len(words)-1]
+	}
+
+	// Find the command being entered and whether the last word is the value of one of its flags
+	cmd := rootCmd
+	var valueFlag *pflag.Flag
+	device := ""
+	for i, w := range words {
+		if valueFlag != nil {
+			if valueFlag.Name == "device" {
+				device = w
+			}
+			valueFlag = nil
+			continue
+		}
+		if strings.HasPrefix(w, "-") {
+			name, value, hasValue := strings.Cut(w, "=")
+			f := lookupShellFlag(cmd, name)
+			if f == nil {
+				continue
+			}
+			if hasValue {
+				if f.Name == "device" {
+					device = value
+				}
+			} else if f.NoOptDefVal == "" {
+				valueFlag = f
+			}
+			continue
+		}
+		if sub := findSubcommand(cmd, w); sub != nil && i == countCommandWords(words[:i]) {
+			cmd = sub
+		}
+	}
+
+	var candidates []string
+	prefix := ""
+	switch {
+	case valueFlag != nil:
+		candidates = getShellNames(getFlagNameKind(cmd, valueFlag.Name), device)
+	case strings.HasPrefix(word, "-") && strings.Contains(word, "="):
+		name, value, _ := strings.Cut(word, "=")
+		if f := lookupShellFlag(cmd, name); f != nil {
+			prefix = name + "="
+			word = value
+			candidates = getShellNames(getFlagNameKind(cmd, f.Name), device)
+		}
+	case strings.HasPrefix(word, "-"):
+		addFlag := func(f *pflag.Flag) {
+			if !f.Hidden {
+				candidates = append(candidates, "--"+f.Name)
+			}
+		}
+		cmd.LocalFlags().VisitAll(addFlag)
+		cmd.InheritedFlags().VisitAll(addFlag)
+	case cmd.HasAvailableSubCommands():
+		for _, sub := range cmd.Commands() {
+			if sub.IsAvailableCommand() && sub != shellCmd {
+				candidates = append(candidates, sub.Name())
+			}
+		}
+		if cmd == rootCmd {
+			candidates = append(candidates, "exit", "history", "quit")
+		}
+	case strings.Contains(cmd.Use, "[name]") && cmd.HasParent():
+		candidates = getShellNames(getGroupNameKind(cmd.Parent().Name()), "")
+	}
+
+	var completions []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, word) {
+			completions = append(completions, prefix+c)
+		}
+	}
+	return completions
+}
+
+// countCommandWords returns the number of leading words that are command names,
+// so that subcommands are only looked up before the first flag or argument
+func countCommandWords(words []string) int {
+	cmd := rootCmd
+	for i, w := range words {
+		sub := findSubcommand(cmd, w)
+		if sub == nil {
+			return i
+		}
+		cmd = sub
+	}
+	return len(words)
+}
+
+func findSubcommand(cmd *cobra.Command, name string) *cobra.Command {
+	for _, sub := range cmd.Commands() {
+		if sub.Name() == name || sub.HasAlias(name) {
+			return sub
+		}
+	}
+	return nil
+}
+
+func lookupShellFlag(cmd *cobra.Command, name string) *pflag.Flag {
+	var f *pflag.Flag
+	if strings.HasPrefix(name, "--") {
+		name = strings.TrimPrefix(name, "--")
+		if f = cmd.Flags().Lookup(name); f == nil {
+			f = cmd.InheritedFlags().Lookup(name)
+		}
+	} else if len(name) == 2 {
+		name = strings.TrimPrefix(name, "-")
+		if f = cmd.Flags().ShorthandLookup(name); f == nil {
+			f = cmd.InheritedFlags().ShorthandLookup(name)
+		}
+	}
+	return f
+}
+
+// getShellNames returns the names of the given kind, or none if they can't be fetched
+func getShellNames(kind string, device string) []string {
+	if kind == "" {
+		return nil
+	}
+	names, err := getResourceNames(kind, device)
+	if err != nil {
+		return nil
+	}
+	return names
+}
+
+func getShellHistoryPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, shellHistoryFile)
+}
+
+func loadShellHistory(path string) []string {
+	var history []string
+	if path == "" {
+		return history
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return history
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			history = append(history, line)
+		}
+	}
+	return history
+}
+
+func saveShellHistory(path string, history []string) {
+	if path == "" {
+		return
+	}
+	if len(history) > shellHistorySize {
+		history = history[len(history)-shellHistorySize:]
+	}
+	content := strings.Join(history, "\n")
+	if content != "" {
+		content += "\n"
+	}
+	_ = os.WriteFile(path, []byte(content), 0600)
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitShellLine(t *testing.T) {
+	tests := []struct {
+		name         string
+		line         string
+		expectedArgs []string
+		expectError  bool
+	}{
+		{"empty line", "", nil, false},
+		{"blank line", "  \t ", nil, false},
+		{"words", "event list --limit 5", []string{"event", "list", "--limit", "5"}, false},
+		{"repeated spaces", "  event \t list  ", []string{"event", "list"}, false},
+		{"double quotes", `device add -n "my device"`, []string{"device", "add", "-n", "my device"}, false},
+		{"single quotes", `notification add -c 'a "b" c'`, []string{"notification", "add", "-c", `a "b" c`}, false},
+		{"quotes within word", `--labels=a" "b`, []string{"--labels=a b"}, false},
+		{"empty quotes", `device add -n ""`, []string{"device", "add", "-n", ""}, false},
+		{"escaped space", `my\ device`, []string{"my device"}, false},
+		{"escaped quote in double quotes", `"a \"b\""`, []string{`a "b"`}, false},
+		{"backslash in single quotes", `'a\b'`, []string{`a\b`}, false},
+		{"unterminated double quote", `device add -n "my device`, nil, true},
+		{"unterminated single quote", `'a`, nil, true},
+		{"trailing backslash", `event list \`, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, err := splitShellLine(tt.line)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %q", args)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("expected %q, got %q", tt.expectedArgs, args)
+			}
+		})
+	}
+}
+
+func TestCompleteShellLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		before   string
+		expected []string
+	}{
+		{"command", "ver", []string{"version"}},
+		{"several commands", "dev", []string{"device", "deviceprofile", "deviceservice"}},
+		{"shell command", "ex", []string{"exit"}},
+		{"subcommands", "event ", []string{"add", "count", "generate", "list", "rm"}},
+		{"subcommand", "event li", []string{"list"}},
+		{"flag", "event list --li", []string{"--limit"}},
+		{"inherited flag", "event list --timez", []string{"--timezone"}},
+		{"flag after flag value", "event list --limit 5 --off", []string{"--offset"}},
+		{"unknown command", "unknown x", nil},
+		{"no match", "event x", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			completions := completeShellLine(tt.before)
+			if len(completions) == 0 && len(tt.expected) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(completions, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, completions)
+			}
+		})
+	}
+}
+
+func TestResetCommandFlags(t *testing.T) {
+	list, _, err := rootCmd.Find([]string{"device", "list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := list.Flags().Set("limit", "5"); err != nil {
+		t.Fatal(err)
+	}
+	if err := list.Flags().Set("labels", "a,b"); err != nil {
+		t.Fatal(err)
+	}
+	resetCommandFlags(rootCmd)
+	if limit != 50 {
+		t.Errorf("expected the limit to be reset, got %d", limit)
+	}
+	if list.Flags().Changed("limit") {
+		t.Error("expected the limit flag to be unchanged")
+	}
+	if labels != "" {
+		t.Errorf("expected the labels to be reset, got %q", labels)
+	}
+}
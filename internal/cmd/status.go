@@ -0,0 +1,430 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/edgexfoundry/edgex-cli/internal/config"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/spf13/cobra"
+)
+
+var statusStale, statusTimeout time.Duration
+
+// The overall statuses of a deployment, and the exit codes of the status command
+const (
+	statusOK   = "OK"
+	statusWarn = "WARN"
+	statusFail = "FAIL"
+)
+
+var statusExitCodes = map[string]int{statusOK: 0, statusWarn: 1, statusFail: 2}
+
+func init() {
+	var cmd = &cobra.Command{
+		Use:   "status",
+		Short: "Summarize the health of the EdgeX deployment",
+		Long: `Summarize the health of the EdgeX deployment: the availability, version and metrics of the services,
+the number of devices by admin and operating state, the devices which haven't reported within --stale,
+the number of events and readings, and the unprocessed critical notifications.
+
+The overall status is FAIL if a service is unreachable or a check fails, WARN if an unlocked device
+is down or stale or a critical notification is unprocessed, and OK otherwise. The command exits with
+0 for OK, 1 for WARN and 2 for FAIL, for use by monitoring tools.`,
+		Example: `  edgex-cli status
+  edgex-cli status --stale 15m --json`,
+		RunE:         handleStatus,
+		SilenceUsage: true,
+	}
+	cmd.Flags().VarP(newDurationValue(time.Hour, &statusStale), "stale", "", "Warn about unlocked devices which haven't reported within this duration, 0 disables the check")
+	cmd.Flags().DurationVarP(&statusTimeout, "timeout", "t", 5*time.Second, "How long to wait for each check")
+	addFormatFlags(cmd)
+	addVerboseFlag(cmd)
+	rootCmd.AddCommand(cmd)
+}
+
+type serviceStatus struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	Version    string `json:"version,omitempty"`
+	Latency    string `json:"latency,omitempty"`
+	CpuBusyAvg uint8  `json:"cpuBusyAvg,omitempty"`
+	MemAlloc   uint64 `json:"memAlloc,omitempty"`
+}
+
+type staleDevice struct {
+	Name         string `json:"name"`
+	LastReported string `json:"lastReported"`
+}
+
+type deviceStatus struct {
+	Total           int            `json:"total"`
+	AdminStates     map[string]int `json:"adminStates"`
+	OperatingStates map[string]int `json:"operatingStates"`
+	Down            []string       `json:"down,omitempty"`
+	Stale           []staleDevice  `json:"stale,omitempty"`
+	NeverReported   int            `json:"neverReported"`
+	Error           string         `json:"error,omitempty"`
+}
+
+type statusProblem struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// statusReport is the outcome of the status checks
+type statusReport struct {
+	Status                string              `json:"status"`
+	Problems              []statusProblem     `json:"problems"`
+	Services              []serviceStatus     `json:"services"`
+	Devices               deviceStatus        `json:"devices"`
+	EventCount            uint32              `json:"eventCount"`
+	ReadingCount          uint32              `json:"readingCount"`
+	CountError            string              `json:"countError,omitempty"`
+	CriticalNotifications []dtos.Notification `json:"criticalNotifications"`
+	NotificationsError    string              `json:"notificationsError,omitempty"`
+}
+
+// statusCheck runs a check and returns a function recording its outcome in the report
+type statusCheck func() func(*statusReport)
+
+func handleStatus(cmd *cobra.Command, args []string) error {
+	if statusTimeout <= 0 {
+		return errors.New("the timeout must be greater than zero")
+	}
+	report := getStatusReport()
+
+	if json {
+		result, err := jsonpkg.Marshal(report)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(result))
+	} else if err := printStatusReport(report); err != nil {
+		return err
+	}
+
+	if report.Status != statusOK {
+		return exitCodeError{code: statusExitCodes[report.Status], message: "the deployment status is " + report.Status}
+	}
+	return nil
+}
+
+// getStatusReport runs the checks concurrently and evaluates the overall status
+func getStatusReport() statusReport {
+	report := statusReport{CriticalNotifications: []dtos.Notification{}}
+	services := config.GetCoreServices()
+	names := make([]string, 0, len(services))
+	for name := range services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	report.Services = make([]serviceStatus, len(names))
+
+	checks := []statusCheck{checkDeviceStatus, checkDataCounts, checkCriticalNotifications}
+	for i, name := range names {
+		i, name := i, name
+		checks = append(checks, func() func(*statusReport) {
+			return checkServiceStatus(i, name)
+		})
+	}
+
+	results := make(chan func(*statusReport), len(checks))
+	for _, check := range checks {
+		check := check
+		go func() {
+			results <- check()
+		}()
+	}
+	for range checks {
+		(<-results)(&report)
+	}
+
+	evaluateStatus(&report)
+	return report
+}
+
+// runStatusCheck runs check in the background, giving up after the status timeout. The clients
+// don't cancel requests when their context is done, so a check which times out is left to
+// complete in the background and its result is discarded.
+func runStatusCheck(check func() func(*statusReport), failed func(error) func(*statusReport)) func(*statusReport) {
+	done := make(chan func(*statusReport), 1)
+	go func() {
+		done <- check()
+	}()
+	select {
+	case result := <-done:
+		return result
+	case <-time.After(statusTimeout):
+		return failed(fmt.Errorf("timed out after %v", statusTimeout))
+	}
+}
+
+func checkServiceStatus(i int, name string) func(*statusReport) {
+	failed := func(err error) func(*statusReport) {
+		return func(r *statusReport) { r.Services[i] = serviceStatus{Name: name, Status: "DOWN", Error: err.Error()} }
+	}
+	return runStatusCheck(func() func(*statusReport) {
+		client := config.GetCoreService(name).GetCommonClient()
+		started := time.Now()
+		if _, err := client.Ping(context.Background()); err != nil {
+			return failed(err)
+		}
+		status := serviceStatus{Name: name, Status: "UP", Latency: time.Since(started).Round(time.Millisecond).String()}
+		if response, err := client.Version(context.Background()); err == nil {
+			status.Version = response.Version
+		}
+		if response, err := client.Metrics(context.Background()); err == nil {
+			status.CpuBusyAvg = response.Metrics.CpuBusyAvg
+			status.MemAlloc = response.Metrics.MemAlloc
+		}
+		return func(r *statusReport) { r.Services[i] = status }
+	}, failed)
+}
+
+func checkDeviceStatus() func(*statusReport) {
+	failed := func(err error) func(*statusReport) {
+		return func(r *statusReport) { r.Devices.Error = err.Error() }
+	}
+	return runStatusCheck(func() func(*statusReport) {
+		client := getCoreMetaDataService().GetDeviceClient()
+		devices, err := fetchAll(func(offset int, limit int) ([]dtos.Device, uint32, error) {
+			response, err := client.AllDevices(context.Background(), nil, offset, limit)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Devices, response.TotalCount, nil
+		})
+		if err != nil {
+			return failed(err)
+		}
+
+		status := deviceStatus{Total: len(devices), AdminStates: map[string]int{}, OperatingStates: map[string]int{}}
+		staleBefore := time.Now().Add(-statusStale)
+		for _, d := range devices {
+			status.AdminStates[d.AdminState]++
+			status.OperatingStates[d.OperatingState]++
+			if d.AdminState == models.Locked {
+				continue
+			}
+			if d.OperatingState == models.Down {
+				status.Down = append(status.Down, d.Name)
+			}
+			if d.LastReported <= 0 {
+				status.NeverReported++
+				continue
+			}
+			lastReported := getLastReportedTime(d.LastReported)
+			if statusStale > 0 && lastReported.Before(staleBefore) {
+				status.Stale = append(status.Stale, staleDevice{Name: d.Name, LastReported: formatRFC822Time(lastReported)})
+			}
+		}
+		sort.Strings(status.Down)
+		sort.Slice(status.Stale, func(i, j int) bool { return status.Stale[i].Name < status.Stale[j].Name })
+		return func(r *statusReport) { r.Devices = status }
+	}, failed)
+}
+
+func checkDataCounts() func(*statusReport) {
+	failed := func(err error) func(*statusReport) {
+		return func(r *statusReport) { r.CountError = err.Error() }
+	}
+	return runStatusCheck(func() func(*statusReport) {
+		service := getCoreDataService()
+		events, err := service.GetEventClient().EventCount(context.Background())
+		if err != nil {
+			return failed(err)
+		}
+		readings, err := service.GetReadingClient().ReadingCount(context.Background())
+		if err != nil {
+			return failed(err)
+		}
+		return func(r *statusReport) {
+			r.EventCount = events.Count
+			r.ReadingCount = readings.Count
+		}
+	}, failed)
+}
+
+func checkCriticalNotifications() func(*statusReport) {
+	failed := func(err error) func(*statusReport) {
+		return func(r *statusReport) { r.NotificationsError = err.Error() }
+	}
+	return runStatusCheck(func() func(*statusReport) {
+		client := getSupportNotificationsService().GetNotificationClient()
+		notifications, err := getAllNotifications(func(offset int, limit int) (responses.MultiNotificationsResponse, error) {
+			return client.NotificationsByStatus(context.Background(), models.New, offset, limit)
+		})
+		if err != nil {
+			return failed(err)
+		}
+		critical := []dtos.Notification{}
+		for _, n := range notifications {
+			if n.Severity == models.Critical {
+				critical = append(critical, n)
+			}
+		}
+		sort.Slice(critical, func(i, j int) bool { return critical[i].Created > critical[j].Created })
+		return func(r *statusReport) { r.CriticalNotifications = critical }
+	}, failed)
+}
+
+// getLastReportedTime converts the time a device last reported, in milliseconds, though some
+// device services report it in nanoseconds
+func getLastReportedTime(t int64) time.Time {
+	if t > 1e15 {
+		return time.Unix(0, t)
+	}
+	return time.Unix(0, t*int64(time.Millisecond))
+}
+
+// evaluateStatus lists the problems found by the checks and sets the overall status
+func evaluateStatus(report *statusReport) {
+	add := func(level string, format string, args ...interface{}) {
+		report.Problems = append(report.Problems, statusProblem{Level: level, Message: fmt.Sprintf(format, args...)})
+	}
+	for _, s := range report.Services {
+		if s.Status != "UP" {
+			add(statusFail, "%s is unreachable: %s", s.Name, s.Error)
+		}
+	}
+	if report.Devices.Error != "" {
+		add(statusFail, "could not get the devices: %s", report.Devices.Error)
+	}
+	if report.CountError != "" {
+		add(statusFail, "could not count the events and readings: %s", report.CountError)
+	}
+	if report.NotificationsError != "" {
+		add(statusFail, "could not get the notifications: %s", report.NotificationsError)
+	}
+	if n := len(report.Devices.Down); n > 0 {
+		add(statusWarn, "%d unlocked device(s) down: %s", n, summarizeNames(report.Devices.Down))
+	}
+	if n := len(report.Devices.Stale); n > 0 {
+		names := make([]string, n)
+		for i, d := range report.Devices.Stale {
+			names[i] = d.Name
+		}
+		add(statusWarn, "%d unlocked device(s) not reported within %v: %s", n, statusStale, summarizeNames(names))
+	}
+	if n := len(report.CriticalNotifications); n > 0 {
+		add(statusWarn, "%d unprocessed critical notification(s)", n)
+	}
+
+	report.Status = statusOK
+	for _, p := range report.Problems {
+		if p.Level == statusFail {
+			report.Status = statusFail
+			break
+		}
+		report.Status = statusWarn
+	}
+	if report.Problems == nil {
+		report.Problems = []statusProblem{}
+	}
+}
+
+// summarizeNames joins the first names of a list, unless verbose output is requested
+func summarizeNames(names []string) string {
+	const shown = 5
+	if verbose || len(names) <= shown {
+		return strings.Join(names, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(names[:shown], ", "), len(names)-shown)
+}
+
+// formatStateCounts formats counts of states, e.g. "UP 5, DOWN 1"
+func formatStateCounts(counts map[string]int) string {
+	states := make([]string, 0, len(counts))
+	for state := range counts {
+		states = append(states, state)
+	}
+	sort.Strings(states)
+	formatted := make([]string, len(states))
+	for i, state := range states {
+		name := state
+		if name == "" {
+			name = "(none)"
+		}
+		formatted[i] = fmt.Sprintf("%s %d", name, counts[state])
+	}
+	return strings.Join(formatted, ", ")
+}
+
+func printStatusReport(report statusReport) error {
+	fmt.Printf("Status: %s\n", report.Status)
+	for _, p := range report.Problems {
+		fmt.Printf("  %-4s  %s\n", p.Level, p.Message)
+	}
+	fmt.Println()
+
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(w, "Service\tStatus\tVersion\tLatency\tCpuBusyAvg\tMemAlloc")
+	for _, s := range report.Services {
+		if s.Status != "UP" {
+			fmt.Fprintf(w, "%s\t%s\t\t\t\t\n", s.Name, s.Status)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d%%\t%d\n", s.Name, s.Status, s.Version, s.Latency, s.CpuBusyAvg, s.MemAlloc)
+	}
+	w.Flush()
+	fmt.Println()
+
+	if report.Devices.Error == "" {
+		fmt.Fprintf(w, "Devices\t%d\n", report.Devices.Total)
+		fmt.Fprintf(w, "  AdminState\t%s\n", formatStateCounts(report.Devices.AdminStates))
+		fmt.Fprintf(w, "  OperatingState\t%s\n", formatStateCounts(report.Devices.OperatingStates))
+		if statusStale > 0 {
+			fmt.Fprintf(w, "  Stale (%v)\t%d\n", statusStale, len(report.Devices.Stale))
+		}
+		fmt.Fprintf(w, "  Never reported\t%d\n", report.Devices.NeverReported)
+	}
+	if report.CountError == "" {
+		fmt.Fprintf(w, "Events\t%d\n", report.EventCount)
+		fmt.Fprintf(w, "Readings\t%d\n", report.ReadingCount)
+	}
+	if report.NotificationsError == "" {
+		fmt.Fprintf(w, "Unprocessed critical notifications\t%d\n", len(report.CriticalNotifications))
+	}
+	w.Flush()
+
+	if verbose && len(report.Devices.Stale) > 0 {
+		fmt.Println()
+		fmt.Fprintln(w, "Stale Device\tLastReported")
+		for _, d := range report.Devices.Stale {
+			fmt.Fprintf(w, "%s\t%s\n", d.Name, d.LastReported)
+		}
+		w.Flush()
+	}
+	if verbose && len(report.CriticalNotifications) > 0 {
+		fmt.Println()
+		return printRows(notificationTable, report.CriticalNotifications)
+	}
+	return nil
+}
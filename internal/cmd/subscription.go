@@ -21,9 +21,7 @@ import (
 	jsonpkg "encoding/json"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
-	"text/tabwriter"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
@@ -43,6 +41,7 @@ func init() {
 	initAddSubscriptionCommand(cmd)
 	initListSubscriptionCommand(cmd)
 	initGetSubscriptionByNameCommand(cmd)
+	initUpdateSubscriptionCommand(cmd)
 }
 
 // initRmSubscriptionCommand implements the DELETE /subscription/name/{name} endpoint
@@ -64,15 +63,46 @@ var subscriptionName, subscriptionChannels, subscriptionReceiver string
 var subscriptionCategories, subscriptionDescription, subscriptionResendInterval string
 var subscriptionResendLimit int
 var subscriptionAdminState, subscriptionSelectedCategory, subscriptionSelectedLabel, subscriptionSelectedReceiver string
+var subscriptionId string
+var subscriptionRESTChannels, subscriptionEmailChannels, subscriptionMQTTChannels []string
+
+func addSubscriptionChannelFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&subscriptionChannels, "channels", "c", "", "A JSON object array indicating how this subscription is capable of receiving notifications")
+	cmd.Flags().StringArrayVarP(&subscriptionRESTChannels, "rest-channel", "", nil, "A REST channel as host:port[/path][,method=POST], may be repeated")
+	cmd.Flags().StringArrayVarP(&subscriptionEmailChannels, "email-channel", "", nil, "An email channel as a comma-delimited list of recipients, may be repeated")
+	cmd.Flags().StringArrayVarP(&subscriptionMQTTChannels, "mqtt-channel", "", nil, "An MQTT channel as host:port,topic=TOPIC[,publisher=NAME][,qos=N][,retained=true], may be repeated")
+}
 
+// getSubscriptionChannels returns the channels given as JSON and using the typed channel flags
 func getSubscriptionChannels() (channels []dtos.Address, err error) {
 	if subscriptionChannels != "" {
 		err = jsonpkg.Unmarshal([]byte(subscriptionChannels), &channels)
 		if err != nil {
-			err = fmt.Errorf("channels JSON object array invalid (%v)", err)
+			return nil, fmt.Errorf("channels JSON object array invalid (%v)", err)
+		}
+	}
+	for _, spec := range subscriptionRESTChannels {
+		address, err := parseRESTAddress(spec)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, address)
+	}
+	for _, spec := range subscriptionEmailChannels {
+		address, err := parseEmailAddress(spec)
+		if err != nil {
+			return nil, err
 		}
+		channels = append(channels, address)
 	}
-	return
+	for _, spec := range subscriptionMQTTChannels {
+		address, err := parseMQTTAddress(spec)
+		if err != nil {
+			return nil, err
+		}
+		channels = append(channels, address)
+	}
+	return channels, nil
 }
 
 func getSubscriptionCategories() []string {
@@ -88,16 +118,18 @@ func getSubscriptionCategories() []string {
 // "Adds one or more notifications to be sent."
 func initAddSubscriptionCommand(cmd *cobra.Command) {
 	var add = &cobra.Command{
-		Use:          "add",
-		Short:        "Add a new subscription",
-		Long:         "Add a new subscription",
-		Example:      `  edgex-cli subscription add -n "name01" --receiver "receiver01" -c "[{\"type\": \"REST\", \"host\": \"localhost\", \"port\": 7770, \"httpMethod\": \"POST\"}]"`,
+		Use:   "add",
+		Short: "Add a new subscription",
+		Long:  "Add a new subscription",
+		Example: `  edgex-cli subscription add -n "name01" --receiver "receiver01" --categories "category01" -c "[{\"type\": \"REST\", \"host\": \"localhost\", \"port\": 7770, \"httpMethod\": \"POST\"}]"
+  edgex-cli subscription add -n "name01" --receiver "receiver01" --categories "category01" --rest-channel "localhost:7770/alerts,method=POST"
+  edgex-cli subscription add -n "name01" --receiver "receiver01" --labels "critical" --email-channel "ops@example.com,oncall@example.com"`,
 		RunE:         handleAddSubscription,
 		SilenceUsage: true,
 	}
 
 	add.Flags().StringVarP(&subscriptionName, "name", "n", "", "A meaningful identifier for the subscription")
-	add.Flags().StringVarP(&subscriptionChannels, "channels", "c", "", "A JSON object array indicating how this subscription is capable of receiving notifications")
+	addSubscriptionChannelFlags(add)
 	add.Flags().StringVarP(&subscriptionCategories, "categories", "", "", "A comma-delimited list of categories")
 	add.Flags().StringVarP(&subscriptionReceiver, "receiver", "", "", "The name of the party interested in the notification")
 	add.Flags().StringVarP(&subscriptionDescription, "description", "", "", "An optional description of the subscription's intent.")
@@ -108,12 +140,37 @@ func initAddSubscriptionCommand(cmd *cobra.Command) {
 	addLabelsFlag(add)
 	add.MarkFlagRequired("name")
 	add.MarkFlagRequired("receiver")
-	add.MarkFlagRequired("channels")
-	//admin-state"
-	//channels
 	cmd.AddCommand(add)
 }
 
+// initUpdateSubscriptionCommand implements the PATCH /subscription endpoint
+// "Update one or more existing subscriptions"
+func initUpdateSubscriptionCommand(cmd *cobra.Command) {
+	var update = &cobra.Command{
+		Use:   "update",
+		Short: "Update an existing subscription",
+		Long: `Update an existing subscription, specifying either ID or name.
+Any other provided non-blank property will be updated. If any channel is specified,
+the channels of the subscription are replaced.`,
+		Example: `  edgex-cli subscription update -n "name01" --rest-channel "10.0.0.5:7770/alerts"
+  edgex-cli subscription update -n "name01" --email-channel "ops@example.com" --mqtt-channel "broker:1883,topic=alerts"`,
+		RunE:         handleUpdateSubscription,
+		SilenceUsage: true,
+	}
+	update.Flags().StringVarP(&subscriptionId, "id", "", "", "Uniquely identifies the subscription, either id or name should be specified")
+	update.Flags().StringVarP(&subscriptionName, "name", "n", "", "Subscription name, either id or name should be specified")
+	addSubscriptionChannelFlags(update)
+	update.Flags().StringVarP(&subscriptionCategories, "categories", "", "", "A comma-delimited list of categories")
+	update.Flags().StringVarP(&subscriptionReceiver, "receiver", "", "", "The name of the party interested in the notification")
+	update.Flags().StringVarP(&subscriptionDescription, "description", "", "", "An optional description of the subscription's intent.")
+	update.Flags().IntVarP(&subscriptionResendLimit, "resend-limit", "", 0, "The retry limit for attempts to send notifications")
+	// the defaults are shared with the add command, so these are only updated when specified
+	update.Flags().StringVarP(&subscriptionResendInterval, "resend-interval", "", "1h", "The interval in ISO 8691 format of resending the notification")
+	update.Flags().StringVarP(&subscriptionAdminState, "admin-state", "a", "UNLOCKED", "Admin state [LOCKED | UNLOCKED]")
+	addLabelsFlag(update)
+	cmd.AddCommand(update)
+}
+
 // initListSubscriptionCommand implements a number of endpoints:
 // GET /subscription/all
 // "Allows paginated retrieval of subscriptions, sorted by created timestamp descending."
@@ -138,6 +195,7 @@ func initListSubscriptionCommand(cmd *cobra.Command) {
 	listCmd.Flags().StringVarP(&subscriptionSelectedLabel, "label", "", "", "List subscriptions associated with this label")
 	listCmd.Flags().StringVarP(&subscriptionSelectedReceiver, "receiver", "r", "", "List subscriptions associated with this receiver")
 	addFormatFlags(listCmd)
+	addTableFlags(listCmd)
 	addVerboseFlag(listCmd)
 	addLimitOffsetFlags(listCmd)
 	cmd.AddCommand(listCmd)
@@ -156,6 +214,7 @@ func initGetSubscriptionByNameCommand(cmd *cobra.Command) {
 	nameCmd.Flags().StringVarP(&subscriptionName, "name", "n", "", "Subscription name")
 	nameCmd.MarkFlagRequired("name")
 	addFormatFlags(nameCmd)
+	addTableFlags(nameCmd)
 	addVerboseFlag(nameCmd)
 	cmd.AddCommand(nameCmd)
 
@@ -183,6 +242,9 @@ func handleAddSubscription(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if len(channels) == 0 {
+		return errors.New("at least one channel must be specified")
+	}
 
 	l := getLabels()
 	c := getSubscriptionCategories()
@@ -215,6 +277,66 @@ func handleAddSubscription(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+func handleUpdateSubscription(cmd *cobra.Command, args []string) error {
+	client := getSupportNotificationsService().GetSubscriptionClient()
+
+	var id, name, receiver, description, resendInterval, adminState *string
+	var resendLimit *int
+
+	if subscriptionId != "" {
+		id = &subscriptionId
+	}
+	if subscriptionName != "" {
+		name = &subscriptionName
+	}
+	if id == nil && name == nil {
+		return errors.New("either id or name should be specified")
+	}
+	if subscriptionReceiver != "" {
+		receiver = &subscriptionReceiver
+	}
+	if subscriptionDescription != "" {
+		description = &subscriptionDescription
+	}
+	if cmd.Flags().Changed("resend-interval") {
+		resendInterval = &subscriptionResendInterval
+	}
+	if cmd.Flags().Changed("resend-limit") {
+		resendLimit = &subscriptionResendLimit
+	}
+	if cmd.Flags().Changed("admin-state") {
+		err := validateAdminState(subscriptionAdminState)
+		if err != nil {
+			return err
+		}
+		adminState = &subscriptionAdminState
+	}
+
+	channels, err := getSubscriptionChannels()
+	if err != nil {
+		return err
+	}
+
+	var req = requests.NewUpdateSubscriptionRequest(dtos.UpdateSubscription{
+		Id:             id,
+		Name:           name,
+		Channels:       channels,
+		Receiver:       receiver,
+		Categories:     getSubscriptionCategories(),
+		Labels:         getLabels(),
+		Description:    description,
+		ResendLimit:    resendLimit,
+		ResendInterval: resendInterval,
+		AdminState:     adminState,
+	})
+
+	response, err := client.Update(context.Background(), []requests.UpdateSubscriptionRequest{req})
+	if response != nil {
+		fmt.Println(response[0])
+	}
+	return err
+}
+
 func handleListSubscription(cmd *cobra.Command, args []string) error {
 
 	client := getSupportNotificationsService().GetSubscriptionClient()
@@ -248,12 +370,7 @@ func handleListSubscription(cmd *cobra.Command, args []string) error {
 			fmt.Println("No subscriptions available")
 			return nil
 		}
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printSubscriptionTableHeader(w)
-		for _, n := range response.Subscriptions {
-			printSubscription(w, &n)
-		}
-		w.Flush()
+		return printRows(subscriptionTable, response.Subscriptions)
 	}
 	return nil
 }
@@ -274,42 +391,24 @@ func handleGetSubscriptionByName(cmd *cobra.Command, args []string) error {
 
 		fmt.Println(string(result))
 	} else {
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printSubscriptionTableHeader(w)
-		printSubscription(w, &response.Subscription)
-		w.Flush()
+		return printRows(subscriptionTable, []dtos.Subscription{response.Subscription})
 	}
 	return nil
 }
 
-func printSubscriptionTableHeader(w *tabwriter.Writer) {
-	if verbose {
-		fmt.Fprintln(w, "Id\tName\tDescription\tChannels\tReceiver\tCategories\tLabels\tResendLimit\tResendInterval\tAdminState")
-	} else {
-		fmt.Fprintln(w, "Namet\tDescription\tChannels\tReceiver\tCategories\tLabels")
-	}
-}
-
-func printSubscription(w *tabwriter.Writer, n *dtos.Subscription) {
-	if verbose {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			n.Id,
-			n.Name,
-			n.Description,
-			n.Channels,
-			n.Receiver,
-			n.Categories,
-			n.Labels,
-			n.ResendLimit,
-			n.ResendInterval,
-			n.AdminState)
-	} else {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\n",
-			n.Name,
-			n.Description,
-			n.Channels,
-			n.Receiver,
-			n.Categories,
-			n.Labels)
-	}
+// subscriptionTable defines the columns of tables of subscriptions
+var subscriptionTable = table{
+	columns: []column{
+		newColumn("Id", func(s *dtos.Subscription) interface{} { return s.Id }),
+		newColumn("Name", func(s *dtos.Subscription) interface{} { return s.Name }),
+		newColumn("Description", func(s *dtos.Subscription) interface{} { return s.Description }),
+		newColumn("Channels", func(s *dtos.Subscription) interface{} { return s.Channels }),
+		newColumn("Receiver", func(s *dtos.Subscription) interface{} { return s.Receiver }),
+		newColumn("Categories", func(s *dtos.Subscription) interface{} { return s.Categories }),
+		newColumn("Labels", func(s *dtos.Subscription) interface{} { return s.Labels }),
+		newColumn("ResendLimit", func(s *dtos.Subscription) interface{} { return s.ResendLimit }),
+		newColumn("ResendInterval", func(s *dtos.Subscription) interface{} { return s.ResendInterval }),
+		newColumn("AdminState", func(s *dtos.Subscription) interface{} { return s.AdminState }),
+	},
+	defaults: []string{"Name", "Description", "Channels", "Receiver", "Categories", "Labels"},
 }
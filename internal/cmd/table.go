@@ -0,0 +1,316 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/spf13/cobra"
+)
+
+var tableColumns, tableSortBy string
+var tableNoHeaders, tableWide bool
+
+// tablePadding is the number of spaces between the columns of tables
+const tablePadding = 2
+
+// tableCellReplacer replaces the tabs and line breaks which would break the layout of tables
+var tableCellReplacer = strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+
+// minTruncatedWidth is the width below which columns aren't truncated to fit the terminal
+const minTruncatedWidth = 8
+
+func addTableFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&tableColumns, "columns", "", "", "Comma-separated list of the columns to show, e.g. name,labels, or all for all the columns")
+	cmd.Flags().StringVarP(&tableSortBy, "sort-by", "", "", "Sort the rows by this column, prefixed with - to sort in descending order. Only the rows of the page selected with --offset and --limit are sorted")
+	cmd.Flags().BoolVarP(&tableNoHeaders, "no-headers", "", false, "Don't show the column headers")
+	cmd.Flags().BoolVarP(&tableWide, "wide", "", false, "Don't truncate the columns to fit the width of the terminal")
+}
+
+// column defines a column of a table
+type column struct {
+	name string
+	// value returns the value of the column for a row, used to sort the rows
+	value func(row interface{}) interface{}
+	// format formats the value, if not formatted with %v
+	format func(value interface{}) string
+}
+
+// newColumn returns a column of a table of items of type T, whose value is returned by f
+func newColumn[T any](name string, f func(*T) interface{}) column {
+	return column{name: name, value: func(row interface{}) interface{} { return f(row.(*T)) }}
+}
+
+// withFormat returns the column with its values formatted by the given function
+func (c column) withFormat(format func(value interface{}) string) column {
+	c.format = format
+	return c
+}
+
+func (c column) formatValue(row interface{}) string {
+	value := c.value(row)
+	if c.format != nil {
+		return c.format(value)
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// formatMillis formats a time in milliseconds since the epoch
+func formatMillis(value interface{}) string {
+	return getRFC822Time(value.(int64))
+}
+
+// formatNanos formats a time in nanoseconds since the epoch
+func formatNanos(value interface{}) string {
+	return formatRFC822Time(time.Unix(0, value.(int64)))
+}
+
+// table defines the columns of a table of resources
+type table struct {
+	// columns are all the columns, in the order they are shown with --verbose
+	columns []column
+	// defaults are the names of the columns shown without --verbose
+	defaults []string
+	// verbose are the names of the columns shown with --verbose, if not all of them
+	verbose []string
+}
+
+// normalizeColumnName returns the name of a column in lower case without spaces or symbols,
+// so that e.g. "lastReported" selects LastReported and "devicecommands" selects "# DeviceCommands"
+func normalizeColumnName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, name)
+}
+
+// findColumn returns the column with the given name, which may omit a "Name" suffix,
+// so that e.g. "service" selects ServiceName
+func (t table) findColumn(name string) (column, error) {
+	normalized := normalizeColumnName(name)
+	if normalized == "" {
+		return column{}, fmt.Errorf("invalid column name %q", name)
+	}
+	for _, suffix := range []string{"", "name"} {
+		for _, c := range t.columns {
+			if normalizeColumnName(c.name) == normalized+suffix {
+				return c, nil
+			}
+		}
+	}
+	names := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		names[i] = c.name
+	}
+	return column{}, fmt.Errorf("unknown column %q, expected one of %s", name, strings.Join(names, ", "))
+}
+
+// selectColumns returns the columns chosen with --columns, or by default those shown with or without --verbose
+func (t table) selectColumns() ([]column, error) {
+	if tableColumns == "all" || (tableColumns == "" && verbose && t.verbose == nil) {
+		return t.columns, nil
+	}
+	names := t.defaults
+	if tableColumns == "" && verbose {
+		names = t.verbose
+	} else if tableColumns != "" {
+		names = strings.Split(tableColumns, ",")
+	}
+	columns := make([]column, 0, len(names))
+	for _, name := range names {
+		c, err := t.findColumn(strings.TrimSpace(name))
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, c)
+	}
+	return columns, nil
+}
+
+// sortRows sorts the rows by the column chosen with --sort-by, if any. The rows are those of the page
+// returned by the service, as the services can only sort by their own criteria.
+func (t table) sortRows(rows []interface{}) error {
+	if tableSortBy == "" {
+		return nil
+	}
+	name := strings.TrimPrefix(tableSortBy, "-")
+	descending := name != tableSortBy
+	c, err := t.findColumn(name)
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if descending {
+			return compareValues(c.value(rows[j]), c.value(rows[i])) < 0
+		}
+		return compareValues(c.value(rows[i]), c.value(rows[j])) < 0
+	})
+	return nil
+}
+
+// compareValues compares numbers numerically and other values by their formatted strings
+func compareValues(a interface{}, b interface{}) int {
+	x, xNumber := toFloat(a)
+	y, yNumber := toFloat(b)
+	if xNumber && yNumber {
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprintf("%v", a), fmt.Sprintf("%v", b))
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// printTable prints rows of resources as a table, with the columns, order, headers and width chosen
+// with the table flags
+func printTable(t table, rows []interface{}) error {
+	columns, err := t.selectColumns()
+	if err != nil {
+		return err
+	}
+	if err := t.sortRows(rows); err != nil {
+		return err
+	}
+
+	var lines [][]string
+	if !tableNoHeaders {
+		header := make([]string, len(columns))
+		for i, c := range columns {
+			header[i] = c.name
+		}
+		lines = append(lines, header)
+	}
+	for _, row := range rows {
+		cells := make([]string, len(columns))
+		for i, c := range columns {
+			cells[i] = tableCellReplacer.Replace(c.formatValue(row))
+		}
+		lines = append(lines, cells)
+	}
+	truncateTable(lines, getTableWidth())
+
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, tablePadding, ' ', 0)
+	for _, cells := range lines {
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	return w.Flush()
+}
+
+// printRows prints items of type T as a table, using pointers to the items as rows
+func printRows[T any](t table, items []T) error {
+	rows := make([]interface{}, len(items))
+	for i := range items {
+		rows[i] = &items[i]
+	}
+	return printTable(t, rows)
+}
+
+// getTableWidth returns the width tables are truncated to, which is the width of the terminal
+// when the output is a terminal and --wide isn't given, or 0 otherwise
+func getTableWidth() int {
+	if tableWide || !isTerminal(os.Stdout) {
+		return 0
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	_, columns, err := getTerminalSize(os.Stdout)
+	if err != nil {
+		return 0
+	}
+	return columns
+}
+
+// truncateTable truncates the widest cells of a table so that its lines fit the width, if positive.
+// Cells are truncated to the largest width allowing the table to fit, but not below minTruncatedWidth.
+func truncateTable(lines [][]string, width int) {
+	if width <= 0 || len(lines) == 0 {
+		return
+	}
+	widths := make([]int, len(lines[0]))
+	for _, cells := range lines {
+		for i, cell := range cells {
+			if n := utf8.RuneCountInString(cell); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	tableWidth := func(limit int) int {
+		total := tablePadding * (len(widths) - 1)
+		for _, w := range widths {
+			if w > limit {
+				w = limit
+			}
+			total += w
+		}
+		return total
+	}
+
+	limit := 0
+	for _, w := range widths {
+		if w > limit {
+			limit = w
+		}
+	}
+	if tableWidth(limit) <= width {
+		return
+	}
+	for limit > minTruncatedWidth && tableWidth(limit) > width {
+		limit--
+	}
+	for _, cells := range lines {
+		for i, cell := range cells {
+			if utf8.RuneCountInString(cell) > limit {
+				cells[i] = string([]rune(cell)[:limit-1]) + "…"
+			}
+		}
+	}
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+type testRow struct {
+	name    string
+	count   int
+	created int64
+}
+
+var testTable = table{
+	columns: []column{
+		newColumn("Name", func(r *testRow) interface{} { return r.name }),
+		newColumn("# Items", func(r *testRow) interface{} { return r.count }),
+		newColumn("Created", func(r *testRow) interface{} { return r.created }).withFormat(formatMillis),
+		newColumn("ServiceName", func(r *testRow) interface{} { return "service-" + r.name }),
+	},
+	defaults: []string{"Name", "# Items"},
+}
+
+func getColumnNames(columns []column) []string {
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	return names
+}
+
+func TestSelectColumns(t *testing.T) {
+	tests := []struct {
+		name          string
+		table         table
+		columns       string
+		verbose       bool
+		expectedNames []string
+		expectError   bool
+	}{
+		{"defaults", testTable, "", false, []string{"Name", "# Items"}, false},
+		{"verbose", testTable, "", true, []string{"Name", "# Items", "Created", "ServiceName"}, false},
+		{"verbose columns", table{columns: testTable.columns, verbose: []string{"Name", "Created"}}, "", true, []string{"Name", "Created"}, false},
+		{"all", testTable, "all", false, []string{"Name", "# Items", "Created", "ServiceName"}, false},
+		{"chosen columns", testTable, "created,name", false, []string{"Created", "Name"}, false},
+		{"chosen columns with verbose", testTable, "created", true, []string{"Created"}, false},
+		{"normalized names", testTable, " items , CREATED", false, []string{"# Items", "Created"}, false},
+		{"name without suffix", testTable, "service", false, []string{"ServiceName"}, false},
+		{"unknown column", testTable, "name,size", false, nil, true},
+		{"empty column", testTable, "name,", false, nil, true},
+		{"column without letters", testTable, "name,#", false, nil, true},
+	}
+	defer func() {
+		tableColumns = ""
+		verbose = false
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tableColumns = tt.columns
+			verbose = tt.verbose
+			columns, err := tt.table.selectColumns()
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %v", getColumnNames(columns))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if names := getColumnNames(columns); !reflect.DeepEqual(names, tt.expectedNames) {
+				t.Errorf("expected %v, got %v", tt.expectedNames, names)
+			}
+		})
+	}
+}
+
+func TestSortRows(t *testing.T) {
+	items := []testRow{{"b", 10, 3}, {"c", 9, 1}, {"a", 10, 2}}
+	tests := []struct {
+		name          string
+		sortBy        string
+		expectedNames []string
+		expectError   bool
+	}{
+		{"unsorted", "", []string{"b", "c", "a"}, false},
+		{"strings", "name", []string{"a", "b", "c"}, false},
+		{"descending", "-name", []string{"c", "b", "a"}, false},
+		{"numbers", "items", []string{"c", "b", "a"}, false},
+		{"numbers descending and stable", "-items", []string{"b", "a", "c"}, false},
+		{"formatted values", "created", []string{"c", "a", "b"}, false},
+		{"unknown column", "size", nil, true},
+		{"empty column", "-", nil, true},
+	}
+	defer func() { tableSortBy = "" }()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tableSortBy = tt.sortBy
+			rows := make([]interface{}, len(items))
+			for i := range items {
+				item := items[i]
+				rows[i] = &item
+			}
+			err := testTable.sortRows(rows)
+			if tt.expectError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := make([]string, len(rows))
+			for i, row := range rows {
+				names[i] = row.(*testRow).name
+			}
+			if !reflect.DeepEqual(names, tt.expectedNames) {
+				t.Errorf("expected %v, got %v", tt.expectedNames, names)
+			}
+		})
+	}
+}
+
+func TestCompareValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		a        interface{}
+		b        interface{}
+		expected int
+	}{
+		{"numbers", 9, 10, -1},
+		{"numbers of different types", int64(10), 9.5, 1},
+		{"numeric strings", "9", "10", -1},
+		{"equal numbers", uint32(3), 3.0, 0},
+		{"strings", "10a", "9a", -1},
+		{"number and string", 10, "a", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := compareValues(tt.a, tt.b); result != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestTruncateTable(t *testing.T) {
+	tests := []struct {
+		name     string
+		lines    [][]string
+		width    int
+		expected [][]string
+	}{
+		{"no width", [][]string{{"Name", "Description"}, {"device1", "a long description"}}, 0,
+			[][]string{{"Name", "Description"}, {"device1", "a long description"}}},
+		{"fitting table", [][]string{{"Name", "Description"}, {"device1", "a long description"}}, 27,
+			[][]string{{"Name", "Description"}, {"device1", "a long description"}}},
+		{"widest column truncated", [][]string{{"Name", "Description"}, {"device1", "a long description"}}, 20,
+			[][]string{{"Name", "Description"}, {"device1", "a long des…"}}},
+		{"several columns truncated", [][]string{{"Name", "Description"}, {"a long name", "a long description"}}, 22,
+			[][]string{{"Name", "Descripti…"}, {"a long na…", "a long de…"}}},
+		{"minimum width", [][]string{{"Name", "Description"}, {"a long name", "a long description"}}, 10,
+			[][]string{{"Name", "Descrip…"}, {"a long …", "a long …"}}},
+		{"multi-byte characters", [][]string{{"Name"}, {"température"}}, 9,
+			[][]string{{"Name"}, {"températ…"}}},
+		{"empty table", nil, 10, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			truncateTable(tt.lines, tt.width)
+			if !reflect.DeepEqual(tt.lines, tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, tt.lines)
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timezone is the name of the location used to render times and to interpret
+// times given without a zone, set by the global --timezone flag
+var timezone string
+var timeLocation = time.Local
+
+// intervalTimeLayout is the ISO 8601 basic format used by support-scheduler intervals,
+// which is always interpreted as UTC
+const intervalTimeLayout = "20060102T150405"
+
+// zonedTimeLayouts are the accepted time formats which include a zone
+var zonedTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC822Z,
+	time.RFC822,
+}
+
+// localTimeLayouts are the accepted time formats without a zone, which are
+// interpreted in the --timezone location
+var localTimeLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+const timeArgHelp = "RFC3339, RFC822, YYYY-MM-DD[ HH:mm[:ss]] in the --timezone location, " +
+	"YYYYMMDD'T'HHmmss in UTC, an epoch timestamp in s, ms or ns, " +
+	"now, today, yesterday or a duration relative to now (e.g. -2h, -7d, 3h ago)"
+
+// setTimeLocation sets the location used to render and interpret times
+func setTimeLocation(name string) error {
+	switch strings.ToLower(name) {
+	case "", "local":
+		timeLocation = time.Local
+	case "utc":
+		timeLocation = time.UTC
+	default:
+		location, err := time.LoadLocation(name)
+		if err != nil {
+			return fmt.Errorf("invalid timezone %q: %v", name, err)
+		}
+		timeLocation = location
+	}
+	return nil
+}
+
+// formatRFC822Time renders a time in RFC822 format in the --timezone location
+func formatRFC822Time(t time.Time) string {
+	return t.In(timeLocation).Format(time.RFC822)
+}
+
+// parseTimeArg parses a time given on the command line. See timeArgHelp for the accepted formats.
+func parseTimeArg(t string) (time.Time, error) {
+	t = strings.TrimSpace(t)
+	now := time.Now().In(timeLocation)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, timeLocation)
+	switch strings.ToLower(t) {
+	case "now":
+		return now, nil
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	for _, layout := range zonedTimeLayouts {
+		if result, err := time.Parse(layout, t); err == nil {
+			return result, nil
+		}
+	}
+	for _, layout := range localTimeLayouts {
+		if result, err := time.ParseInLocation(layout, t, timeLocation); err == nil {
+			return result, nil
+		}
+	}
+	// the support-scheduler format is UTC, as it is for interval start and end times
+	if result, err := time.Parse(intervalTimeLayout, t); err == nil {
+		return result, nil
+	}
+
+	if strings.HasPrefix(t, "-") || strings.HasPrefix(t, "+") {
+		if d, err := parseDurationArg(t); err == nil {
+			return now.Add(d), nil
+		}
+	}
+	if strings.HasSuffix(t, " ago") {
+		if d, err := parseDurationArg(strings.TrimSpace(strings.TrimSuffix(t, " ago"))); err == nil {
+			return now.Add(-d), nil
+		}
+	}
+
+	if epoch, err := strconv.ParseInt(t, 10, 64); err == nil {
+		switch {
+		case epoch < 1e11:
+			return time.Unix(epoch, 0), nil
+		case epoch < 1e14:
+			return time.Unix(0, epoch*int64(time.Millisecond)), nil
+		default:
+			return time.Unix(0, epoch), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, expected %s", t, timeArgHelp)
+}
+
+// getMillisTimestamp parses a time given on the command line into milliseconds since the epoch
+func getMillisTimestamp(t string) (int64, error) {
+	result, err := parseTimeArg(t)
+	if err != nil {
+		return 0, err
+	}
+	return result.UnixNano() / int64(time.Millisecond), nil
+}
+
+// getIntervalTime converts a time given on the command line into the format used by
+// support-scheduler intervals, which interprets it as UTC. Times already in that format
+// are passed through unchanged.
+func getIntervalTime(t string) (string, error) {
+	if t == "" {
+		return "", nil
+	}
+	if _, err := time.Parse(intervalTimeLayout, t); err == nil {
+		return t, nil
+	}
+	result, err := parseTimeArg(t)
+	if err != nil {
+		return "", err
+	}
+	return result.UTC().Format(intervalTimeLayout), nil
+}
+
+// parseDurationArg parses a duration given on the command line. In addition to the
+// units accepted by time.ParseDuration, d (days) and w (weeks) may be used, e.g. 7d or 1d12h.
+// A bare number is taken as milliseconds, the unit used by the EdgeX APIs.
+func parseDurationArg(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Duration(ms) * time.Millisecond, nil
+	}
+
+	sign := time.Duration(1)
+	rest := s
+	if strings.HasPrefix(rest, "-") || strings.HasPrefix(rest, "+") {
+		if rest[0] == '-' {
+			sign = -1
+		}
+		rest = rest[1:]
+	}
+	if rest == "" {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var total time.Duration
+	for rest != "" {
+		i := strings.IndexFunc(rest, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return 0, fmt.Errorf("invalid duration %q, expected e.g. 90s, 15m, 2h or 7d", s)
+		}
+		j := i + strings.IndexFunc(rest[i:], func(r rune) bool { return (r >= '0' && r <= '9') || r == '.' })
+		if j < i {
+			j = len(rest)
+		}
+		unit := rest[i:j]
+		if unit == "d" || unit == "w" {
+			n, err := strconv.ParseFloat(rest[:i], 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q", s)
+			}
+			day := 24 * time.Hour
+			if unit == "w" {
+				day *= 7
+			}
+			total += time.Duration(n * float64(day))
+		} else {
+			d, err := time.ParseDuration(rest[:j])
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q, expected e.g. 90s, 15m, 2h or 7d", s)
+			}
+			total += d
+		}
+		rest = rest[j:]
+	}
+	return sign * total, nil
+}
+
+// durationValue is a flag value holding a non-negative duration parsed by parseDurationArg
+type durationValue time.Duration
+
+func newDurationValue(value time.Duration, p *time.Duration) *durationValue {
+	*p = value
+	return (*durationValue)(p)
+}
+
+func (d *durationValue) Set(s string) error {
+	v, err := parseDurationArg(s)
+	if err != nil {
+		return err
+	}
+	if v < 0 {
+		return fmt.Errorf("invalid duration %q, it must not be negative", s)
+	}
+	*d = durationValue(v)
+	return nil
+}
+
+func (d *durationValue) Type() string {
+	return "duration"
+}
+
+func (d *durationValue) String() string {
+	return time.Duration(*d).String()
+}
+
+// getNanosTimeRange converts the start and end arguments into a nanosecond time range.
+// A missing start defaults to the epoch and a missing end defaults to now.
+func getNanosTimeRange(start string, end string) (int, int, error) {
+	startTime := time.Unix(0, 0)
+	endTime := time.Now()
+	var err error
+	if start != "" {
+		startTime, err = parseTimeArg(start)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if end != "" {
+		endTime, err = parseTimeArg(end)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+	if endTime.Before(startTime) {
+		return 0, 0, fmt.Errorf("end time %s is before start time %s", endTime.Format(time.RFC3339), startTime.Format(time.RFC3339))
+	}
+	return int(startTime.UnixNano()), int(endTime.UnixNano()), nil
+}
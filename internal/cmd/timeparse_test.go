@@ -0,0 +1,257 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+// withTimeLocation runs f with the --timezone location set to the named location
+func withTimeLocation(t *testing.T, name string, f func()) {
+	previous := timeLocation
+	defer func() { timeLocation = previous }()
+	if err := setTimeLocation(name); err != nil {
+		t.Fatal(err)
+	}
+	f()
+}
+
+func TestParseTimeArg(t *testing.T) {
+	tests := []struct {
+		name        string
+		arg         string
+		expected    time.Time
+		expectError bool
+	}{
+		{"RFC3339", "2022-03-04T05:06:07Z", time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC), false},
+		{"RFC3339 with offset", "2022-03-04T05:06:07+02:00", time.Date(2022, 3, 4, 3, 6, 7, 0, time.UTC), false},
+		{"RFC822Z", "04 Mar 22 05:06 +0000", time.Date(2022, 3, 4, 5, 6, 0, 0, time.UTC), false},
+		{"date and time in the --timezone location", "2022-03-04 05:06:07", time.Date(2022, 3, 4, 4, 6, 7, 0, time.UTC), false},
+		{"date and minutes in the --timezone location", "2022-03-04 05:06", time.Date(2022, 3, 4, 4, 6, 0, 0, time.UTC), false},
+		{"date in the --timezone location", "2022-03-04", time.Date(2022, 3, 3, 23, 0, 0, 0, time.UTC), false},
+		{"ISO 8601 basic format in UTC", "20220304T050607", time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC), false},
+		{"epoch seconds", "1646370367", time.Date(2022, 3, 4, 5, 6, 7, 0, time.UTC), false},
+		{"epoch milliseconds", "1646370367123", time.Date(2022, 3, 4, 5, 6, 7, 123000000, time.UTC), false},
+		{"epoch nanoseconds", "1646370367123456789", time.Date(2022, 3, 4, 5, 6, 7, 123456789, time.UTC), false},
+		{"invalid", "next tuesday", time.Time{}, true},
+		{"invalid date", "2022-13-01", time.Time{}, true},
+	}
+	withTimeLocation(t, "Europe/Paris", func() {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				actual, err := parseTimeArg(tt.arg)
+				if tt.expectError {
+					if err == nil {
+						t.Errorf("expected an error, got %v", actual)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !actual.Equal(tt.expected) {
+					t.Errorf("expected %v, got %v", tt.expected, actual.UTC())
+				}
+			})
+		}
+	})
+}
+
+func TestParseTimeArgRelative(t *testing.T) {
+	tests := []struct {
+		name     string
+		arg      string
+		expected time.Duration
+	}{
+		{"now", "now", 0},
+		{"negative duration", "-2h", -2 * time.Hour},
+		{"positive duration", "+30m", 30 * time.Minute},
+		{"days", "-7d", -7 * 24 * time.Hour},
+		{"ago", "3h ago", -3 * time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := time.Now()
+			actual, err := parseTimeArg(tt.arg)
+			after := time.Now()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual.Before(before.Add(tt.expected)) || actual.After(after.Add(tt.expected)) {
+				t.Errorf("expected %v relative to now, got %v", tt.expected, actual)
+			}
+		})
+	}
+
+	withTimeLocation(t, "UTC", func() {
+		now := time.Now().UTC()
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		for arg, expected := range map[string]time.Time{
+			"today":     today,
+			"yesterday": today.AddDate(0, 0, -1),
+			"tomorrow":  today.AddDate(0, 0, 1),
+		} {
+			actual, err := parseTimeArg(arg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !actual.Equal(expected) {
+				t.Errorf("expected %s to be %v, got %v", arg, expected, actual)
+			}
+		}
+	})
+}
+
+func TestParseDurationArg(t *testing.T) {
+	tests := []struct {
+		name        string
+		arg         string
+		expected    time.Duration
+		expectError bool
+	}{
+		{"milliseconds", "1500", 1500 * time.Millisecond, false},
+		{"Go duration", "1h30m", 90 * time.Minute, false},
+		{"fractional", "1.5h", 90 * time.Minute, false},
+		{"days", "7d", 7 * 24 * time.Hour, false},
+		{"weeks", "2w", 14 * 24 * time.Hour, false},
+		{"days and hours", "1d12h", 36 * time.Hour, false},
+		{"negative", "-2h", -2 * time.Hour, false},
+		{"positive sign", "+15m", 15 * time.Minute, false},
+		{"surrounding spaces", " 10s ", 10 * time.Second, false},
+		{"sign only", "-", 0, true},
+		{"unit only", "h", 0, true},
+		{"unknown unit", "3y", 0, true},
+		{"empty", "", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseDurationArg(tt.arg)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %v", actual)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDurationValue(t *testing.T) {
+	tests := []struct {
+		name        string
+		arg         string
+		expected    time.Duration
+		expectError bool
+	}{
+		{"duration", "12h", 12 * time.Hour, false},
+		{"days", "7d", 7 * 24 * time.Hour, false},
+		{"milliseconds", "60000", time.Minute, false},
+		{"zero", "0", 0, false},
+		{"negative", "-1h", time.Minute, true},
+		{"invalid", "soon", time.Minute, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d time.Duration
+			value := newDurationValue(time.Minute, &d)
+			err := value.Set(tt.arg)
+			if tt.expectError != (err != nil) {
+				t.Fatalf("expected error %v, got %v", tt.expectError, err)
+			}
+			if d != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, d)
+			}
+			if value.String() != tt.expected.String() {
+				t.Errorf("expected %s, got %s", tt.expected, value.String())
+			}
+		})
+	}
+}
+
+func TestGetIntervalTime(t *testing.T) {
+	tests := []struct {
+		name        string
+		arg         string
+		expected    string
+		expectError bool
+	}{
+		{"empty", "", "", false},
+		{"ISO 8601 basic format passes through", "20220304T050607", "20220304T050607", false},
+		{"RFC3339 is converted to UTC", "2022-03-04T05:06:07+02:00", "20220304T030607", false},
+		{"local time is converted to UTC", "2022-03-04 05:06", "20220304T040600", false},
+		{"invalid", "whenever", "", true},
+	}
+	withTimeLocation(t, "Europe/Paris", func() {
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				actual, err := getIntervalTime(tt.arg)
+				if tt.expectError {
+					if err == nil {
+						t.Errorf("expected an error, got %s", actual)
+					}
+					return
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+				if actual != tt.expected {
+					t.Errorf("expected %s, got %s", tt.expected, actual)
+				}
+			})
+		}
+	})
+}
+
+func TestGetNanosTimeRange(t *testing.T) {
+	tests := []struct {
+		name          string
+		start         string
+		end           string
+		expectedStart int
+		expectedEnd   int
+		expectError   bool
+	}{
+		{"start and end", "2022-03-04T00:00:00Z", "2022-03-05T00:00:00Z", 1646352000000000000, 1646438400000000000, false},
+		{"missing start is the epoch", "", "2022-03-05T00:00:00Z", 0, 1646438400000000000, false},
+		{"end before start", "2022-03-05T00:00:00Z", "2022-03-04T00:00:00Z", 0, 0, true},
+		{"invalid start", "someday", "", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, err := getNanosTimeRange(tt.start, tt.end)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("expected an error, got %d %d", start, end)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if start != tt.expectedStart || end != tt.expectedEnd {
+				t.Errorf("expected %d %d, got %d %d", tt.expectedStart, tt.expectedEnd, start, end)
+			}
+		})
+	}
+}
@@ -20,9 +20,8 @@ import (
 	"context"
 	jsonpkg "encoding/json"
 	"fmt"
-	"os"
 	"strings"
-	"text/tabwriter"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
 	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/responses"
@@ -31,20 +30,22 @@ import (
 )
 
 var transmissionId string
-var transmissionAge int
+var transmissionAge time.Duration
 var transmissionSubscriptionName, transmissionStart, transmissionEnd, transmissionStatus string
 
 func init() {
 	var cmd = &cobra.Command{
 		Use:          "transmission",
-		Short:        "Remove and list transmissions [Support Notifications]",
-		Long:         "Remove and list transmissions [Support Notifications]",
+		Short:        "Remove, list, report and retry transmissions [Support Notifications]",
+		Long:         "Remove, list, report and retry transmissions [Support Notifications]",
 		SilenceUsage: true,
 	}
 	rootCmd.AddCommand(cmd)
 	initRmTransmissionCommand(cmd)
 	initListTransmissionCommand(cmd)
 	initGetTransmissionByIdCommand(cmd)
+	initReportTransmissionCommand(cmd)
+	initRetryTransmissionCommand(cmd)
 }
 
 // initRmTransmissionCommand implements the DELETE /transmission/age/{age}
@@ -54,11 +55,11 @@ func initRmTransmissionCommand(cmd *cobra.Command) {
 	var rm = &cobra.Command{
 		Use:          "rm",
 		Short:        "Delete processed transmissions",
-		Long:         "Delete processed transmissions older than the specificed age (in milliseconds)",
+		Long:         "Delete processed transmissions older than the specificed age (e.g. 7d, 12h, or in milliseconds)",
 		RunE:         handleRmTransmission,
 		SilenceUsage: true,
 	}
-	rm.Flags().IntVarP(&transmissionAge, "age", "a", 0, "The minimum age of transmissions to deleted (in milliseconds)")
+	rm.Flags().VarP(newDurationValue(0, &transmissionAge), "age", "a", "The minimum age of transmissions to deleted (e.g. 7d, 12h, or in milliseconds)")
 	rm.MarkFlagRequired("age")
 	cmd.AddCommand(rm)
 }
@@ -83,15 +84,17 @@ func initListTransmissionCommand(cmd *cobra.Command) {
 		Example: `  edgex-cli transmission list
   edgex-cli transmission list --name "name01"
   edgex-cli transmission list --status "SENT"
-  edgex-cli transmission list --start "01 jan 20 00:00 GMT" --end "01 dec 21 00:00 GMT"`,
+  edgex-cli transmission list --start "01 jan 20 00:00 GMT" --end "01 dec 21 00:00 GMT"
+  edgex-cli transmission list --start -7d --end now`,
 		RunE:         handleListTransmission,
 		SilenceUsage: true,
 	}
 	listCmd.Flags().StringVarP(&transmissionSubscriptionName, "name", "n", "", "List transmissions that originated with the specified subscription")
-	listCmd.Flags().StringVarP(&transmissionStart, "start", "s", "", "List transmissions from after this (RFC822) timestamp")
-	listCmd.Flags().StringVarP(&transmissionEnd, "end", "e", "", "List transmissions from before this (RFC822) timestamp")
+	listCmd.Flags().StringVarP(&transmissionStart, "start", "s", "", "List transmissions from after this time ("+timeArgHelp+")")
+	listCmd.Flags().StringVarP(&transmissionEnd, "end", "e", "", "List transmissions from before this time ("+timeArgHelp+")")
 	listCmd.Flags().StringVarP(&transmissionStatus, "status", "", "", "List transmissions with this status [ACKNOWLEDGED, FAILED, SENT, RESENDING, ESCALATED]")
 	addFormatFlags(listCmd)
+	addTableFlags(listCmd)
 	addVerboseFlag(listCmd)
 	addLimitOffsetFlags(listCmd)
 	cmd.AddCommand(listCmd)
@@ -110,6 +113,7 @@ func initGetTransmissionByIdCommand(cmd *cobra.Command) {
 	nameCmd.Flags().StringVarP(&transmissionId, "id", "i", "", "The ID that identifies the transmission")
 	nameCmd.MarkFlagRequired("id")
 	addFormatFlags(nameCmd)
+	addTableFlags(nameCmd)
 	addVerboseFlag(nameCmd)
 	cmd.AddCommand(nameCmd)
 
@@ -117,7 +121,7 @@ func initGetTransmissionByIdCommand(cmd *cobra.Command) {
 
 func handleRmTransmission(cmd *cobra.Command, args []string) error {
 	client := getSupportNotificationsService().GetTransmissionClient()
-	response, err := client.DeleteProcessedTransmissionsByAge(context.Background(), transmissionAge)
+	response, err := client.DeleteProcessedTransmissionsByAge(context.Background(), int(transmissionAge.Milliseconds()))
 	if err == nil {
 		fmt.Println(response.Message)
 	}
@@ -143,11 +147,11 @@ func handleListTransmission(cmd *cobra.Command, args []string) error {
 		}
 		response, err = client.TransmissionsByStatus(context.Background(), transmissionStatus, offset, limit)
 	} else if transmissionStart != "" && transmissionEnd != "" {
-		start, err := getMillisTimestampFromRFC822Time(transmissionStart)
+		start, err := getMillisTimestamp(transmissionStart)
 		if err != nil {
 			return err
 		}
-		end, err := getMillisTimestampFromRFC822Time(transmissionEnd)
+		end, err := getMillisTimestamp(transmissionEnd)
 		if err != nil {
 			return err
 		}
@@ -173,12 +177,7 @@ func handleListTransmission(cmd *cobra.Command, args []string) error {
 			fmt.Println("No transmissions available")
 			return nil
 		}
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printTransmissionTableHeader(w)
-		for _, n := range response.Transmissions {
-			printTransmission(w, &n)
-		}
-		w.Flush()
+		return printRows(transmissionTable, response.Transmissions)
 	}
 	return nil
 }
@@ -199,39 +198,22 @@ func handleGetTransmissionById(cmd *cobra.Command, args []string) error {
 
 		fmt.Println(string(result))
 	} else {
-		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
-		printTransmissionTableHeader(w)
-		printTransmission(w, &response.Transmission)
-		w.Flush()
+		return printRows(transmissionTable, []dtos.Transmission{response.Transmission})
 	}
 	return nil
 }
 
-func printTransmissionTableHeader(w *tabwriter.Writer) {
-	if verbose {
-		fmt.Fprintln(w, "Id\tChannel\tCreated\tNotificationId\tSubscriptionName\tRecords\tResendCount\tStatus")
-	} else {
-		fmt.Fprintln(w, "SubscriptionName\tResendCount\tStatus")
-	}
-
-}
-
-func printTransmission(w *tabwriter.Writer, t *dtos.Transmission) {
-	if verbose {
-		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
-			t.Id,
-			t.Channel,
-			getRFC822Time(t.Created),
-			t.NotificationId,
-			t.SubscriptionName,
-			t.Records,
-			t.ResendCount,
-			t.Status)
-	} else {
-		fmt.Fprintf(w, "%v\t%v\t%v\n",
-			t.SubscriptionName,
-			t.ResendCount,
-			t.Status)
-
-	}
+// transmissionTable defines the columns of tables of transmissions
+var transmissionTable = table{
+	columns: []column{
+		newColumn("Id", func(t *dtos.Transmission) interface{} { return t.Id }),
+		newColumn("Channel", func(t *dtos.Transmission) interface{} { return t.Channel }),
+		newColumn("Created", func(t *dtos.Transmission) interface{} { return t.Created }).withFormat(formatMillis),
+		newColumn("NotificationId", func(t *dtos.Transmission) interface{} { return t.NotificationId }),
+		newColumn("SubscriptionName", func(t *dtos.Transmission) interface{} { return t.SubscriptionName }),
+		newColumn("Records", func(t *dtos.Transmission) interface{} { return t.Records }),
+		newColumn("ResendCount", func(t *dtos.Transmission) interface{} { return t.ResendCount }),
+		newColumn("Status", func(t *dtos.Transmission) interface{} { return t.Status }),
+	},
+	defaults: []string{"SubscriptionName", "ResendCount", "Status"},
 }
@@ -0,0 +1,307 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/requests"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/models"
+	"github.com/spf13/cobra"
+)
+
+var transmissionSince time.Duration
+var transmissionDryRun bool
+
+func initReportTransmissionCommand(cmd *cobra.Command) {
+	var reportCmd = &cobra.Command{
+		Use:   "report",
+		Short: "Summarize transmissions by subscription and status",
+		Long: `Group transmissions by subscription and status, showing the number of transmissions,
+the total resend count, and the most recent error recorded for failed transmissions`,
+		Example: `  edgex-cli transmission report
+  edgex-cli transmission report --since 1h
+  edgex-cli transmission report --name "name01" --since 0`,
+		RunE:         handleReportTransmission,
+		SilenceUsage: true,
+	}
+	addTransmissionSelectionFlags(reportCmd)
+	addFormatFlags(reportCmd)
+	cmd.AddCommand(reportCmd)
+}
+
+func initRetryTransmissionCommand(cmd *cobra.Command) {
+	var retryCmd = &cobra.Command{
+		Use:   "retry",
+		Short: "Re-send the notifications of failed transmissions",
+		Long: `Re-send the notifications associated with FAILED transmissions.
+Each notification is sent again as a new notification with the same content, category and labels,
+so it is delivered to every subscription matching it and not only the one that failed.`,
+		Example: `  edgex-cli transmission retry --name "name01" --dry-run
+  edgex-cli transmission retry --since 30m`,
+		RunE:         handleRetryTransmission,
+		SilenceUsage: true,
+	}
+	addTransmissionSelectionFlags(retryCmd)
+	retryCmd.Flags().BoolVarP(&transmissionDryRun, "dry-run", "", false, "Show the notifications that would be re-sent without sending them")
+	addFormatFlags(retryCmd)
+	cmd.AddCommand(retryCmd)
+}
+
+func addTransmissionSelectionFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&transmissionSubscriptionName, "name", "n", "", "Only include transmissions that originated with the specified subscription")
+	cmd.Flags().VarP(newDurationValue(24*time.Hour, &transmissionSince), "since", "", "Only include transmissions created within this duration, 0 includes all transmissions")
+}
+
+// transmissionQuery returns a page of transmissions
+type transmissionQuery func(offset int, limit int) (responses.MultiTransmissionsResponse, error)
+
+// getSelectedTransmissions returns all the transmissions selected by the --name and --since flags
+func getSelectedTransmissions() ([]dtos.Transmission, error) {
+	client := getSupportNotificationsService().GetTransmissionClient()
+	var start int64
+	if transmissionSince > 0 {
+		start = time.Now().Add(-transmissionSince).UnixNano() / int64(time.Millisecond)
+	}
+
+	var query transmissionQuery
+	if transmissionSubscriptionName != "" {
+		query = func(offset int, limit int) (responses.MultiTransmissionsResponse, error) {
+			return client.TransmissionsBySubscriptionName(context.Background(), transmissionSubscriptionName, offset, limit)
+		}
+	} else if start > 0 {
+		end := time.Now().UnixNano() / int64(time.Millisecond)
+		query = func(offset int, limit int) (responses.MultiTransmissionsResponse, error) {
+			return client.TransmissionsByTimeRange(context.Background(), int(start), int(end), offset, limit)
+		}
+	} else {
+		query = func(offset int, limit int) (responses.MultiTransmissionsResponse, error) {
+			return client.AllTransmissions(context.Background(), offset, limit)
+		}
+	}
+
+	transmissions, err := getAllTransmissions(query)
+	if err != nil {
+		return nil, err
+	}
+	var selected []dtos.Transmission
+	for _, t := range transmissions {
+		if t.Created >= start {
+			selected = append(selected, t)
+		}
+	}
+	return selected, nil
+}
+
+func getAllTransmissions(query transmissionQuery) ([]dtos.Transmission, error) {
+	return fetchAll(func(offset int, limit int) ([]dtos.Transmission, uint32, error) {
+		response, err := query(offset, limit)
+		if err != nil {
+			return nil, 0, err
+		}
+		return response.Transmissions, response.TotalCount, nil
+	})
+}
+
+// transmissionReportEntry summarizes the transmissions of a subscription having the same status
+type transmissionReportEntry struct {
+	SubscriptionName string `json:"subscriptionName"`
+	Status           string `json:"status"`
+	Count            int    `json:"count"`
+	Failures         int    `json:"failures"`
+	ResendCount      int    `json:"resendCount"`
+	LastCreated      int64  `json:"lastCreated"`
+	LastError        string `json:"lastError,omitempty"`
+	lastErrorSent    int64
+}
+
+// getTransmissionReport groups the transmissions by subscription and status,
+// sorted by subscription name and status
+func getTransmissionReport(transmissions []dtos.Transmission) []*transmissionReportEntry {
+	entries := make(map[string]*transmissionReportEntry)
+	var report []*transmissionReportEntry
+	for _, t := range transmissions {
+		key := t.SubscriptionName + "\x00" + t.Status
+		entry, ok := entries[key]
+		if !ok {
+			entry = &transmissionReportEntry{SubscriptionName: t.SubscriptionName, Status: t.Status}
+			entries[key] = entry
+			report = append(report, entry)
+		}
+		entry.Count++
+		entry.ResendCount += t.ResendCount
+		if t.Created > entry.LastCreated {
+			entry.LastCreated = t.Created
+		}
+		for _, r := range t.Records {
+			if r.Status != models.Failed {
+				continue
+			}
+			entry.Failures++
+			if r.Sent >= entry.lastErrorSent {
+				entry.lastErrorSent = r.Sent
+				entry.LastError = r.Response
+			}
+		}
+	}
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].SubscriptionName != report[j].SubscriptionName {
+			return report[i].SubscriptionName < report[j].SubscriptionName
+		}
+		return report[i].Status < report[j].Status
+	})
+	return report
+}
+
+func handleReportTransmission(cmd *cobra.Command, args []string) error {
+	transmissions, err := getSelectedTransmissions()
+	if err != nil {
+		return err
+	}
+	report := getTransmissionReport(transmissions)
+
+	if json {
+		result, err := jsonpkg.Marshal(report)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(result))
+		return nil
+	}
+
+	if len(report) == 0 {
+		fmt.Println("No transmissions available")
+		return nil
+	}
+	w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+	fmt.Fprintln(w, "SubscriptionName\tStatus\tCount\tFailures\tResendCount\tLast Created\tLast Error")
+	for _, e := range report {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\t%s\t%s\n",
+			e.SubscriptionName,
+			e.Status,
+			e.Count,
+			e.Failures,
+			e.ResendCount,
+			getRFC822Time(e.LastCreated),
+			e.LastError)
+	}
+	w.Flush()
+	return nil
+}
+
+// transmissionRetry is the outcome of re-sending the notification of failed transmissions
+type transmissionRetry struct {
+	NotificationId    string   `json:"notificationId"`
+	SubscriptionNames []string `json:"subscriptionNames"`
+	NewNotificationId string   `json:"newNotificationId,omitempty"`
+	Error             string   `json:"error,omitempty"`
+}
+
+func handleRetryTransmission(cmd *cobra.Command, args []string) error {
+	transmissions, err := getSelectedTransmissions()
+	if err != nil {
+		return err
+	}
+
+	// a notification is only re-sent once, however many of its transmissions failed
+	var retries []*transmissionRetry
+	byNotification := make(map[string]*transmissionRetry)
+	for _, t := range transmissions {
+		if t.Status != models.Failed {
+			continue
+		}
+		retry, ok := byNotification[t.NotificationId]
+		if !ok {
+			retry = &transmissionRetry{NotificationId: t.NotificationId}
+			byNotification[t.NotificationId] = retry
+			retries = append(retries, retry)
+		}
+		retry.SubscriptionNames = append(retry.SubscriptionNames, t.SubscriptionName)
+	}
+
+	failed := 0
+	if !transmissionDryRun {
+		for _, retry := range retries {
+			retry.NewNotificationId, err = resendNotification(retry.NotificationId)
+			if err != nil {
+				retry.Error = err.Error()
+				failed++
+			}
+		}
+	}
+
+	if json {
+		result, err := jsonpkg.Marshal(retries)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(result))
+	} else if len(retries) == 0 {
+		fmt.Println("No failed transmissions")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(w, "NotificationId\tSubscriptionNames\tNewNotificationId\tError")
+		for _, r := range retries {
+			fmt.Fprintf(w, "%s\t%v\t%s\t%s\n", r.NotificationId, r.SubscriptionNames, r.NewNotificationId, r.Error)
+		}
+		w.Flush()
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("unable to re-send %d of %d notifications", failed, len(retries))
+	}
+	return nil
+}
+
+// resendNotification sends a copy of the notification with the given ID, returning the ID of the copy
+func resendNotification(id string) (string, error) {
+	client := getSupportNotificationsService().GetNotificationClient()
+	response, err := client.NotificationById(context.Background(), id)
+	if err != nil {
+		return "", err
+	}
+	n := response.Notification
+	req := requests.NewAddNotificationRequest(dtos.Notification{
+		Category:    n.Category,
+		Labels:      n.Labels,
+		Content:     n.Content,
+		ContentType: n.ContentType,
+		Description: n.Description,
+		Sender:      n.Sender,
+		Severity:    n.Severity,
+	})
+	result, err := client.SendNotification(context.Background(), []requests.AddNotificationRequest{req})
+	if err != nil {
+		return "", err
+	}
+	if len(result) == 0 {
+		return "", fmt.Errorf("no response when re-sending notification %s", id)
+	}
+	if result[0].StatusCode >= http.StatusBadRequest {
+		return "", fmt.Errorf("%s", result[0].Message)
+	}
+	return result[0].Id, nil
+}
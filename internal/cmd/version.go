@@ -19,11 +19,24 @@ package cmd
 import (
 	"context"
 	jsonpkg "encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
 
+	edgex "github.com/edgexfoundry/edgex-cli"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
 	"github.com/spf13/cobra"
 )
 
+var versionCheck bool
+var versionTimeout time.Duration
+
+// versionUncheckedExitCode is the exit code of the version check when no service is incompatible
+// but some couldn't be checked, e.g. because they're unreachable
+const versionUncheckedExitCode = 2
+
 func init() {
 	var cmd = &cobra.Command{
 		Use:          "version",
@@ -35,10 +48,15 @@ func init() {
 
 	rootCmd.AddCommand(cmd)
 	addStandardFlags(cmd)
+	cmd.Flags().BoolVarP(&versionCheck, "check", "", false, "Check the compatibility of the services with edgex-cli, exiting with 1 if any is incompatible or 2 if any couldn't be checked")
+	cmd.Flags().DurationVarP(&versionTimeout, "timeout", "t", 5*time.Second, "How long to wait for each service when checking compatibility")
 
 }
 
 func handleVersion(cmd *cobra.Command, args []string) error {
+	if versionCheck {
+		return handleVersionCheck()
+	}
 	services := getSelectedServices()
 
 	for serviceName, service := range services {
@@ -60,3 +78,51 @@ func handleVersion(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// handleVersionCheck checks the compatibility of the selected services, which are always
+// checked against the services rather than the cached versions
+func handleVersionCheck() error {
+	if versionTimeout <= 0 {
+		return errors.New("the timeout must be greater than zero")
+	}
+	versions := getServiceVersions(getSelectedServices(), false, versionTimeout)
+
+	incompatible, unchecked := 0, 0
+	for _, v := range versions {
+		if v.Error != "" {
+			unchecked++
+		} else if !v.Compatible {
+			incompatible++
+		}
+	}
+
+	if json {
+		result, err := jsonpkg.Marshal(versions)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(result))
+	} else {
+		fmt.Printf("edgex-cli: %s (API %s)\n", edgex.BuildVersion, common.ApiVersion)
+		w := tabwriter.NewWriter(os.Stdout, 1, 1, 2, ' ', 0)
+		fmt.Fprintln(w, "Service\tVersion\tApiVersion\tCompatible\tReason")
+		for _, v := range versions {
+			compatible := "yes"
+			if v.Error != "" {
+				compatible = "UNKNOWN"
+			} else if !v.Compatible {
+				compatible = "NO"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", v.Service, v.Version, v.ApiVersion, compatible, v.Reason)
+		}
+		w.Flush()
+	}
+
+	if incompatible > 0 {
+		return fmt.Errorf("%d of %d services are not compatible with edgex-cli %s", incompatible, len(versions), edgex.BuildVersion)
+	}
+	if unchecked > 0 {
+		return exitCodeError{code: versionUncheckedExitCode, message: fmt.Sprintf("%d of %d services could not be checked", unchecked, len(versions))}
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"context"
+	jsonpkg "encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	edgex "github.com/edgexfoundry/edgex-cli"
+	"github.com/edgexfoundry/edgex-cli/internal/config"
+	"github.com/edgexfoundry/edgex-cli/internal/service"
+	"github.com/edgexfoundry/go-mod-core-contracts/v2/common"
+	"github.com/spf13/cobra"
+)
+
+var skipVersionCheck bool
+
+// versionCheckDone records that the versions were checked before running a command,
+// so that they're only checked once per process, e.g. by the shell
+var versionCheckDone bool
+
+// versionCacheTTL is how long the versions of the services are reused by the check run before commands
+const versionCacheTTL = time.Hour
+
+// versionFailureCacheTTL is how long the failures to get the versions are reused, which is short
+// so that services coming back are soon checked
+const versionFailureCacheTTL = time.Minute
+
+// versionCheckTimeout is how long the check run before commands waits for each service
+const versionCheckTimeout = time.Second
+
+// serviceVersion is the version reported by a service, and its compatibility with the CLI
+type serviceVersion struct {
+	Service    string    `json:"service"`
+	Address    string    `json:"address"`
+	Version    string    `json:"version,omitempty"`
+	ApiVersion string    `json:"apiVersion,omitempty"`
+	Checked    time.Time `json:"checked"`
+	Error      string    `json:"error,omitempty"`
+	Compatible bool      `json:"compatible"`
+	Reason     string    `json:"reason,omitempty"`
+}
+
+// getServiceVersions returns the versions of the given services, sorted by service name.
+// If useCache is true, versions fetched within versionCacheTTL are reused. The failures to
+// reach services are reused within versionFailureCacheTTL, so that commands run in a row don't
+// each wait for unreachable services.
+func getServiceVersions(services map[string]service.Service, useCache bool, timeout time.Duration) []serviceVersion {
+	cache := map[string]serviceVersion{}
+	if useCache {
+		cache = loadVersionCache()
+	}
+
+	versions := make([]serviceVersion, 0, len(services))
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	for name, s := range services {
+		address := fmt.Sprintf("%s:%d", s.Host, s.Port)
+		if cached, ok := cache[name]; ok && isVersionCacheValid(cached, address, time.Now()) {
+			versions = append(versions, cached)
+			continue
+		}
+		wg.Add(1)
+		go func(name string, s service.Service) {
+			defer wg.Done()
+			v := fetchServiceVersion(name, s, timeout)
+			mutex.Lock()
+			versions = append(versions, v)
+			mutex.Unlock()
+		}(name, s)
+	}
+	wg.Wait()
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Service < versions[j].Service })
+
+	for i := range versions {
+		versions[i].Compatible, versions[i].Reason = checkVersionCompatibility(versions[i])
+	}
+	saveVersionCache(cache, versions)
+	return versions
+}
+
+// isVersionCacheValid returns whether a cached version of the service at an address can be reused
+func isVersionCacheValid(cached serviceVersion, address string, now time.Time) bool {
+	ttl := versionCacheTTL
+	if cached.Error != "" {
+		ttl = versionFailureCacheTTL
+	}
+	return cached.Address == address && now.Sub(cached.Checked) < ttl
+}
+
+// fetchServiceVersion gets the version of a service, giving up after the timeout. The clients don't
+// cancel requests when their context is done, so a request which times out is left to complete in
+// the background.
+func fetchServiceVersion(name string, s service.Service, timeout time.Duration) serviceVersion {
+	v := serviceVersion{Service: name, Address: fmt.Sprintf("%s:%d", s.Host, s.Port), Checked: time.Now()}
+	done := make(chan serviceVersion, 1)
+	go func() {
+		result := v
+		response, err := s.GetCommonClient().Version(context.Background())
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Version = response.Version
+			result.ApiVersion = response.ApiVersion
+		}
+		done <- result
+	}()
+	select {
+	case result := <-done:
+		return result
+	case <-time.After(timeout):
+		v.Error = fmt.Sprintf("timed out after %v", timeout)
+		return v
+	}
+}
+
+// checkVersionCompatibility compares the API version of a service with the one of the core contracts
+// used by the CLI, and the major version of the service with the one of the CLI. Versions which
+// aren't known, e.g. those of development builds, are assumed to be compatible.
+func checkVersionCompatibility(v serviceVersion) (bool, string) {
+	if v.Error != "" {
+		return true, "unknown, " + v.Error
+	}
+	if v.ApiVersion != "" && v.ApiVersion != common.ApiVersion {
+		return false, fmt.Sprintf("API version %s, edgex-cli uses %s", v.ApiVersion, common.ApiVersion)
+	}
+	serviceMajor, serviceOK := getMajorVersion(v.Version)
+	cliMajor, cliOK := getMajorVersion(edgex.BuildVersion)
+	if serviceOK && cliOK && serviceMajor != cliMajor {
+		return false, fmt.Sprintf("major version %d, edgex-cli is %d", serviceMajor, cliMajor)
+	}
+	return true, ""
+}
+
+// getMajorVersion returns the major version of a semantic version such as v2.3.0 or 2.3.0-dev.1,
+// or false if the version is unknown or that of a development build, i.e. 0.0.0
+func getMajorVersion(version string) (int, bool) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if version == "" || strings.HasPrefix(version, "0.0.0") {
+		return 0, false
+	}
+	major, err := strconv.Atoi(strings.SplitN(version, ".", 2)[0])
+	if err != nil {
+		return 0, false
+	}
+	return major, true
+}
+
+func loadVersionCache() map[string]serviceVersion {
+	cache := map[string]serviceVersion{}
+	path := getCacheFilePath("versions.json")
+	if path == "" {
+		return cache
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if jsonpkg.Unmarshal(content, &cache) != nil {
+		return map[string]serviceVersion{}
+	}
+	return cache
+}
+
+// saveVersionCache adds the versions, or the failures to get them, to the cache file, on a best effort basis
+func saveVersionCache(cache map[string]serviceVersion, versions []serviceVersion) {
+	path := getCacheFilePath("versions.json")
+	if path == "" {
+		return
+	}
+	for _, v := range versions {
+		cache[v.Service] = v
+	}
+	content, err := jsonpkg.Marshal(cache)
+	if err != nil {
+		return
+	}
+	if os.MkdirAll(filepath.Dir(path), 0700) != nil {
+		return
+	}
+	_ = os.WriteFile(path, content, 0600)
+}
+
+// warnIncompatibleVersions prints a warning for each service which isn't compatible with the CLI.
+// It's run before commands which use the services, unless --skip-version-check is given.
+func warnIncompatibleVersions(cmd *cobra.Command) {
+	if skipVersionCheck || versionCheckDone || !needsVersionCheck(cmd) {
+		return
+	}
+	versionCheckDone = true
+	for _, v := range getServiceVersions(config.GetCoreServices(), true, versionCheckTimeout) {
+		if !v.Compatible {
+			fmt.Fprintf(os.Stderr, "Warning: %s %s is not compatible with edgex-cli %s: %s (use --skip-version-check to hide this warning)\n",
+				v.Service, v.Version, edgex.BuildVersion, v.Reason)
+		}
+	}
+}
+
+// needsVersionCheck returns whether a command uses the services, i.e. isn't one of the commands
+// which run locally or which check the versions themselves
+func needsVersionCheck(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		switch c.Name() {
+		case "version", "help", "completion", "shell", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+			return false
+		}
+	}
+	return cmd.HasParent()
+}
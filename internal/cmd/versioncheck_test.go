@@ -0,0 +1,106 @@
+/*
+ * Copyright (C) 2021 Canonical Ltd
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ *  in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *
+ * SPDX-License-Identifier: Apache-2.0'
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	edgex "github.com/edgexfoundry/edgex-cli"
+)
+
+func TestCheckVersionCompatibility(t *testing.T) {
+	tests := []struct {
+		name               string
+		cliVersion         string
+		version            serviceVersion
+		expectedCompatible bool
+	}{
+		{"same versions", "2.3.0", serviceVersion{Version: "2.3.0", ApiVersion: "v2"}, true},
+		{"different minor versions", "2.3.0", serviceVersion{Version: "2.1.1", ApiVersion: "v2"}, true},
+		{"v prefix", "v2.3.0", serviceVersion{Version: "2.2.0-dev.12", ApiVersion: "v2"}, true},
+		{"different major versions", "2.3.0", serviceVersion{Version: "3.0.0", ApiVersion: "v2"}, false},
+		{"different API versions", "2.3.0", serviceVersion{Version: "3.0.0", ApiVersion: "v3"}, false},
+		{"missing API version", "2.3.0", serviceVersion{Version: "2.3.0"}, true},
+		{"development service", "2.3.0", serviceVersion{Version: "0.0.0", ApiVersion: "v2"}, true},
+		{"development CLI", "0.0.0-dev", serviceVersion{Version: "3.0.0", ApiVersion: "v2"}, true},
+		{"unknown CLI version", "", serviceVersion{Version: "3.0.0", ApiVersion: "v2"}, true},
+		{"invalid service version", "2.3.0", serviceVersion{Version: "latest", ApiVersion: "v2"}, true},
+		{"unreachable service", "2.3.0", serviceVersion{Error: "connection refused"}, true},
+	}
+	cliVersion := edgex.BuildVersion
+	defer func() { edgex.BuildVersion = cliVersion }()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edgex.BuildVersion = tt.cliVersion
+			compatible, reason := checkVersionCompatibility(tt.version)
+			if compatible != tt.expectedCompatible {
+				t.Errorf("expected compatible %v, got %v (%s)", tt.expectedCompatible, compatible, reason)
+			}
+			if !compatible && reason == "" {
+				t.Error("expected a reason")
+			}
+		})
+	}
+}
+
+func TestGetMajorVersion(t *testing.T) {
+	tests := []struct {
+		version       string
+		expectedMajor int
+		expectedOK    bool
+	}{
+		{"2.3.0", 2, true},
+		{"v2.3.0", 2, true},
+		{" 3.0.0-dev.1 ", 3, true},
+		{"2", 2, true},
+		{"0.0.0", 0, false},
+		{"0.0.0-dev", 0, false},
+		{"", 0, false},
+		{"latest", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			major, ok := getMajorVersion(tt.version)
+			if major != tt.expectedMajor || ok != tt.expectedOK {
+				t.Errorf("expected %d %v, got %d %v", tt.expectedMajor, tt.expectedOK, major, ok)
+			}
+		})
+	}
+}
+
+func TestIsVersionCacheValid(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		cached   serviceVersion
+		expected bool
+	}{
+		{"recent version", serviceVersion{Address: "localhost:59880", Checked: now.Add(-30 * time.Minute)}, true},
+		{"expired version", serviceVersion{Address: "localhost:59880", Checked: now.Add(-versionCacheTTL)}, false},
+		{"other address", serviceVersion{Address: "gateway:59880", Checked: now}, false},
+		{"recent failure", serviceVersion{Address: "localhost:59880", Checked: now.Add(-10 * time.Second), Error: "connection refused"}, true},
+		{"expired failure", serviceVersion{Address: "localhost:59880", Checked: now.Add(-versionFailureCacheTTL), Error: "connection refused"}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := isVersionCacheValid(tt.cached, "localhost:59880", now); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}